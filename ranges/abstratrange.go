@@ -1,10 +1,15 @@
 /**
-ranges包抽象了所有由起点和终点（不包括在内）所构成的左闭右开区间—— 形如[Pstart, Pend)
+ranges包抽象了所有由起点和终点所构成的区间，缺省是左闭右开区间—— 形如[Pstart, Pend)，
+区间的起点与终点也可以分别是开边界或闭边界，从而构成[Pstart, Pend]、(Pstart, Pend]或(Pstart, Pend)形式的区间。
 任何具体类型，只要实现了如下几个简单的方法：
-    //Range方法用给定的起点与终点创建一个新的Range
+    //Range方法用给定的起点与终点创建一个新的左闭右开区间
      Range(start, end P) R
+	//RangeWithBounds方法用给定的起点、终点及其边界类型创建一个新的区间
+	RangeWithBounds(start, end P, left, right BoundType) R
 	// DeRange方法将自身析构为起点与终点
 	DeRange() (start, end P)
+	//Bounds方法返回起点与终点的边界类型
+	Bounds() (left, right BoundType)
 	//IsIncludedPoint方法判断给定的点p是否在区间之内
 	IsIncludedPoint(p P) bool
 	//IsBeforePoint方法判断区间是否在给定的点p之前
@@ -33,12 +38,13 @@ package ranges
 
 import "fmt"
 
-//Range是指是由类型参数P的值作为起点和终点（不包括在内）的左闭右开区间。
-//数据结构形如，struct {start P,end P},数学表示形式如， [start,end)
-//从数学上讲，start永远小于end，end永远在start之后，如果二者相等，区间实际上就是一个点。
+//Range是指是由类型参数P的值作为起点和终点的区间，缺省是起点包括在内、终点不包括在内的左闭右开区间。
+//数据结构形如，struct {start P,end P},数学表示形式如， [start,end)，
+//起点与终点也可以分别具有开或闭的边界类型（BoundType），如[start,end]、(start,end]、(start,end)。
+//从数学上讲，start永远小于end，end永远在start之后，如果二者相等，并且都是闭边界，区间[start,start]实际上就是一个点。
 //Range接口中，类型参数P是构成Range起点与终点的点元素的类型,
 //而类型参数R则是实现了Range[P,R]接口的具体类型。
-//这样写，是由于GO不支持嵌套的类型参数定义，无法写Range[P comparable,Range[P,Range]]，
+//这样写，是由于GO不支持嵌套的类型参数定义，无法写Range[P any,Range[P,Range]]，
 //所有用[R any]来代替Range[P,R]，虽然，go1.18不允许直接对泛型的类型参数使用类型断言,比如，r T=P.(T)
 //但是，对于任何满足Range[P，R]的具体类型的值rp，则
 //r R=(interface{})(rp).(R)
@@ -46,29 +52,42 @@ import "fmt"
 //反之，对于任何满足R[P]的具体类型的值r，则
 // rp Range[P,R]=(interface{})(r).(Range[P,R])
 //也是安全的类型转换。
-//这里，还需要注意的是，“零值区间”是一类特殊的区间，该区间的起点和终点都是参数类型P的零值。
-type Range[P comparable, R any] interface {
+//这里，还需要注意的是，“空区间”是一类特殊的区间，该区间不包含任何点，IsEmpty方法返回true，
+//比如，起点与终点相同的左闭右开区间[start,start)。两个区间没有交集或者差集时，ranges包的函数返回空区间，
+//调用者应当使用IsEmpty方法判断结果是否为空区间，而不是将结果的起点与终点同P的零值进行比较。
+//所有的空区间都是相等的，并且都被化为字符串“empty”。
+//ranges包的函数只通过Range接口的方法判断点与点之间的先后，而不会对点使用==，所以类型参数P不必是comparable类型，
+//比如，切片、*big.Int或者含有不可比较字段的结构体都可以作为区间的点，参见CmpRange。
+type Range[P any, R any] interface {
 	//以下方法需要实现者依靠自己去实现
-	// Range方法用给定的起点与终点创建一个新的Range
+	// Range方法用给定的起点与终点创建一个新的左闭右开区间[start,end)
 	Range(start, end P) R
+	//RangeWithBounds方法用给定的起点、终点及其边界类型创建一个新的Range
+	RangeWithBounds(start, end P, left, right BoundType) R
 	// DeRange方法将自身析构为起点与终点
 	DeRange() (start, end P)
+	//Bounds方法返回区间起点（左端点）与终点（右端点）的边界类型
+	Bounds() (left, right BoundType)
 	//IsIncludedPoint方法判断给定的点p是否在区间之内
 	IsIncludedPoint(p P) bool
-	//IsBeforePoint方法判断区间是否在给定的点p之前
+	//IsBeforePoint方法判断区间是否在给定的点p之前，也就是区间内所有的点都在p之前。
 	IsBeforePoint(p P) bool
-	//IsAfterPoint方法判断区间是否在给定的点P之后
+	//IsAfterPoint方法判断区间是否在给定的点P之后，也就是区间内所有的点都在p之后。
+	//对于起点与终点相同的区间，也同样依据起点及其边界类型进行判断。
 	IsAfterPoint(p P) bool
 
 	////////////////////////////////////////////////////////////////
 
 	//以下方法可以借助ranges包提供相应函数，帮助具体类型简化这些方法的实现逻辑。
-	//IsPoint方法判断给定的区间是否是一个点，当区间的起点与终点相同时，区间就是一个点。
+	//IsEmpty方法判断区间是否是空区间，也就是不包含任何点。
+	IsEmpty() bool
+	//IsPoint方法判断给定的区间是否是一个点，当区间的起点与终点相同，并且都是闭边界时，区间就是一个点。
 	IsPoint() bool
-	//将区间化为字符串，缺省的格式[startString,endstring)
+	//将区间化为字符串，缺省的格式[startString,endstring)，括号的形式由起点与终点的边界类型决定，空区间化为“empty”。
 	String() string
 
-	//Equal方法判断区间是否与另一个区间（other）相等。也就是起点与起点相等，终点与终点相等。
+	//Equal方法判断区间是否与另一个区间（other）相等。也就是起点与起点相等，终点与终点相等，并且边界类型相同，
+	//或者两个区间都是空区间。
 	Equal(other R) bool
 	// Union方法求区间与另一个区间(other)的并集，也就是最小的起点与最大的终点所构成的区间，返回false表明结果区间是不相邻区间构成的。
 	Union(other R) (bool, R)
@@ -76,19 +95,21 @@ type Range[P comparable, R any] interface {
 	UnionOthers(others []R) (bool, R)
 	//IsIntersected方法计算是否与其他区间other相交，返回false表示不相交。
 	IsIntersected(other R) bool
-	//Intersect方法计算区间与另一个区间的交集，如果不相交，返回false，并且，结果区间“零值区间”，即：区间起点终点都是零值。
+	//Intersect方法计算区间与另一个区间的交集，如果不相交，返回false，并且，结果区间是空区间。
 	Intersect(other R) (bool, R)
-	// IntersectOthers计算区间与另一些区间的交集，如果都不相交，返回false，并且，结果区间“零值区间”。
+	// IntersectOthers计算区间与另一些区间的交集，如果都不相交，返回false，并且，结果区间是空区间。
 	IntersectOthers(others []R) (bool, R)
 	//Except方法求区间与other差集，也就是去掉other的剩余部分。
 	//返回结果最多是两个区间，r1和r2。
-	//当other是this的真子集时，差集分为两段，r1和r2都是非零值区间。
-	//如果两个集合有部分交集，或者完全不相交，只有一个差集，结果r1是二者的差集，此时r2为零值区间。
-	//如果两个区间相等，或者this是other的真子集，则差集是零值区间，因而，结果区间r1和r2都是零值区间。
+	//当other是this的真子集时，差集分为两段，r1和r2都不是空区间。
+	//如果两个集合有部分交集，或者完全不相交，只有一个差集，结果r1是二者的差集，此时r2为空区间。
+	//如果两个区间相等，或者this是other的真子集，则差集是空区间，因而，结果区间r1和r2都是空区间。
 	Except(other R) (r1, r2 R)
-	//IsBefore计算区间是否在另一个区间(other)之前，也就是区间的终点是否在other区间之前。
+	//ExceptOthers方法求区间去掉另一些区间（others）之后的差集，返回按顺序排列的、非空的剩余区间。
+	ExceptOthers(others []R) []R
+	//IsBefore计算区间是否在另一个区间(other)之前，也就是区间内所有的点是否都在other区间之前。
 	IsBefore(other R) bool
-	//IsAfter计算区间是否在另一个区间（other）之后，也就是区间的起点是否在other区间之后。
+	//IsAfter计算区间是否在另一个区间（other）之后，也就是区间内所有的点是否都在other区间之后。
 	IsAfter(other R) bool
 }
 
@@ -111,68 +132,99 @@ func v2s[V any](v V) string {
 }
 
 ///////////////////下面是ranges包提供的辅助函数,可以帮助接口的实现者快速实现功能/////////////////////////
-//IntersectOthers函数计算两个区间，this与other是否相交。
-func IsIntersected[P comparable, R any](this, other Range[P, R]) bool {
-	thisStart, thisEnd := this.DeRange()
-	otherStart, otherEnd := other.DeRange()
-	isIntersected := (this.IsIncludedPoint(otherStart) || this.IsIncludedPoint(otherEnd) ||
-		other.IsIncludedPoint(thisStart) || other.IsIncludedPoint(thisEnd)) &&
-		thisStart != otherEnd && otherStart != thisEnd
-	return isIntersected
+//下面的函数都依据区间起点与终点的边界类型进行计算，比如，[1,3]与[3,5)相交于点3，
+//[1,3)与[3,5)虽不相交但彼此相邻，而[1,3)与(3,5)既不相交也不相邻，二者之间缺少点3。
+
+//IsIntersected函数计算两个区间，this与other是否相交，也就是是否存在同时属于两个区间的点。
+func IsIntersected[P any, R any](this, other Range[P, R]) bool {
+	thisLower, thisUpper := endpoints(this)
+	otherLower, otherUpper := endpoints(other)
+	return !isEmptySpan(this, maxLower(this, thisLower, otherLower), minUpper(this, thisUpper, otherUpper))
+}
+
+//IsEmpty函数判断给定的区间值r是否是空区间，也就是不包含任何点。
+//如果区间的起点与终点相同，但不都是闭边界，比如[a,a)、(a,a]，区间就不包含任何点。
+func IsEmpty[P any, R any](r Range[P, R]) bool {
+	lower, upper := endpoints(r)
+	return isEmptySpan(r, lower, upper)
 }
 
 //IsPoint函数判断给定的区间值r是否是一个点。
-//如果给定区间值r的起点与终点相等，则返回true,否则返回false
-func IsPoint[P comparable, R any](r Range[P, R]) bool {
+//如果给定区间值r的起点与终点相等，并且都是闭边界，也就是形如[a,a]的区间，则返回true,否则返回false。
+//具有无界端点的区间不是一个点。
+func IsPoint[P any, R any](r Range[P, R]) bool {
 	start, end := r.DeRange()
-	return start == end
+	left, right := r.Bounds()
+	return left == Closed && right == Closed && comparePoints(r, start, end) == 0
 }
 
-//Equql函数判断this与other是否相等。
-func Equal[P comparable, R any](this, other Range[P, R]) bool {
-	thisStart, thisEnd := this.DeRange()
-	otherStart, otherEnd := other.DeRange()
-	return thisStart == otherStart && thisEnd == otherEnd
-
+//emptyOf函数借助区间r创建一个空区间，此函数仅用于ranges包内部使用。
+//空区间的端点取自r的有界端点，因为无界端点的值是P的零值，比如，*big.Rat的nil，不一定能够用来比较。
+//r的两个端点都无界时，如果具体区间类型实现了emptyRanger接口，就使用其emptyRange方法创建空区间。
+func emptyOf[P any, R any](r Range[P, R]) R {
+	e, ok := (interface{})(r).(emptyRanger[R])
+	if ok && e.isEmptyRange() {
+		return e.emptyRange()
+	}
+	start, end := r.DeRange()
+	left, right := r.Bounds()
+	switch {
+	case left != Unbounded:
+		return r.Range(start, start)
+	case right != Unbounded:
+		return r.Range(end, end)
+	case ok:
+		return e.emptyRange()
+	}
+	return r.Range(start, start)
 }
 
-//IsIncludedPoint方法判断other区间是否在this区间之内，是this区间的子集。
-func IsIncluded[P comparable, R any](this, other Range[P, R]) bool {
-	otherStart, otherEnd := other.DeRange()
-	_, thisEnd := this.DeRange()
-	var result bool = this.IsIncludedPoint(otherStart) && (this.IsIncludedPoint(otherEnd) || thisEnd == otherEnd)
-	return result
+//Equql函数判断this与other是否相等，也就是起点与终点分别相等，并且边界类型也相同。
+//所有的空区间都是相等的。
+func Equal[P any, R any](this, other Range[P, R]) bool {
+	if thisEmpty, otherEmpty := IsEmpty(this), IsEmpty(other); thisEmpty || otherEmpty {
+		return thisEmpty && otherEmpty
+	}
+	thisLower, thisUpper := endpoints(this)
+	otherLower, otherUpper := endpoints(other)
+	return compareLower(this, thisLower, otherLower) == 0 && compareUpper(this, thisUpper, otherUpper) == 0
 }
 
-//Intersect函数计算this区间与other区间的交集，如果不相交，返回false，并且，结果区间“零值区间”，即：区间起点终点都是零值。
-func Intersect[P comparable, R any](this, other Range[P, R]) (bool, R) {
-	thisStart, thisEnd := this.DeRange()
-	otherStart, otherEnd := other.DeRange()
-	isIntersected := IsIntersected(this, other)
-	var start, end P // 零值
-	if !isIntersected {
-
-		return isIntersected, this.Range(start, end)
+//IsIncludedPoint方法判断other区间是否在this区间之内，是this区间的子集。空区间是任何区间的子集。
+func IsIncluded[P any, R any](this, other Range[P, R]) bool {
+	if IsEmpty(other) {
+		return true
 	}
-	start, end = thisStart, thisEnd
-	if this.IsIncludedPoint(otherStart) {
-		start = otherStart
+	if IsEmpty(this) {
+		return false
 	}
-	if this.IsIncludedPoint(otherEnd) {
-		end = otherEnd
+	thisLower, thisUpper := endpoints(this)
+	otherLower, otherUpper := endpoints(other)
+	return compareLower(this, thisLower, otherLower) <= 0 && compareUpper(this, otherUpper, thisUpper) <= 0
+}
+
+//Intersect函数计算this区间与other区间的交集，如果不相交，返回false，并且，结果区间是空区间。
+func Intersect[P any, R any](this, other Range[P, R]) (bool, R) {
+	thisLower, thisUpper := endpoints(this)
+	otherLower, otherUpper := endpoints(other)
+	lower, upper := maxLower(this, thisLower, otherLower), minUpper(this, thisUpper, otherUpper)
+	if isEmptySpan(this, lower, upper) {
+		return false, emptyOf(this)
 	}
-	return isIntersected, this.Range(start, end)
+	return true, withEndpoints(this, lower, upper)
 }
 
-// IntersectOthers函数计算区间this与另一些区间(others)的交集，如果与所有其他区间都不相交，返回false，并且，结果区间“零值区间”。
-func IntersectOthers[P comparable, R any](this Range[P, R], others []R) (bool, R) {
-	var existIntersection bool = false
-	var result R
+// IntersectOthers函数计算区间this与另一些区间(others)的交集，如果与所有其他区间都不相交，返回false，并且，结果区间是空区间。
+//依次与others中的区间求交集，只要有一次相交就返回true，结果区间是最终的交集，可能是空区间。
+//如果others中没有区间，同样返回false与空区间。
+func IntersectOthers[P any, R any](this Range[P, R], others []R) (bool, R) {
 	if len(others) == 0 {
-		return false, result
+		return false, emptyOf(this)
 	}
+	var existIntersection bool = false
 	var intersectResult Range[P, R] = this
 	var isIntersected bool
+	var result R
 	for _, r := range others {
 		isIntersected, result = Intersect(intersectResult, typeTo[R, Range[P, R]](r))
 		if isIntersected {
@@ -180,28 +232,42 @@ func IntersectOthers[P comparable, R any](this Range[P, R], others []R) (bool, R
 		}
 		intersectResult = typeTo[R, Range[P, R]](result)
 	}
-	result = typeTo[Range[P, R], R](intersectResult)
 	return existIntersection, result
 }
 
+//isConnected函数判断this区间与other区间是否相交或者相邻，也就是二者之间没有空隙。
+//两个区间不相交时，空隙是从先结束区间的终点到后开始区间的起点之间的部分，
+//此时，先结束区间的终点是空隙的起点，后开始区间的起点是空隙的终点，二者的边界类型需要取反。
+func isConnected[P any, R any](this, other Range[P, R]) bool {
+	thisLower, thisUpper := endpoints(this)
+	otherLower, otherUpper := endpoints(other)
+	lower, upper := maxLower(this, thisLower, otherLower), minUpper(this, thisUpper, otherUpper)
+	if !isEmptySpan(this, lower, upper) {
+		return true
+	}
+	//不相交的两个区间，先结束区间的终点与后开始区间的起点一定都是有界的
+	gapLower := endpoint[P]{upper.value, upper.bound.flip()}
+	gapUpper := endpoint[P]{lower.value, lower.bound.flip()}
+	return isEmptySpan(this, gapLower, gapUpper)
+}
+
 // Union函数求this区间与other区间(other)的并集，也就是最小的起点与最大的终点所构成的区间。false表明结果区间是不相邻区间构成的。
-func Union[P comparable, R any](this, other Range[P, R]) (bool, R) {
-	isIntersected := IsIntersected(this, other)
-	thisStart, thisEnd := this.DeRange()
-	otherStart, otherEnd := other.DeRange()
-	isSuccessive := isIntersected || thisStart == otherEnd || thisEnd == otherStart
-	start, end := thisStart, thisEnd
-	if this.IsAfterPoint(otherStart) {
-		start = otherStart
+//空区间与任何区间的并集都是该区间本身。
+func Union[P any, R any](this, other Range[P, R]) (bool, R) {
+	if IsEmpty(other) {
+		return true, typeTo[Range[P, R], R](this)
 	}
-	if this.IsBeforePoint(otherEnd) {
-		end = otherEnd
+	if IsEmpty(this) {
+		return true, typeTo[Range[P, R], R](other)
 	}
-	return isSuccessive, this.Range(start, end)
+	thisLower, thisUpper := endpoints(this)
+	otherLower, otherUpper := endpoints(other)
+	lower, upper := minLower(this, thisLower, otherLower), maxUpper(this, thisUpper, otherUpper)
+	return isConnected(this, other), withEndpoints(this, lower, upper)
 }
 
 //UnionOthers函数计算this区间与另外一些区间（others）的并集，（最小的起点与最大的终点构成的区间。）如果存在两个区间不相邻，则返回false。
-func UnionOthers[P comparable, R any](this Range[P, R], others []R) (bool, R) {
+func UnionOthers[P any, R any](this Range[P, R], others []R) (bool, R) {
 	var isAllSuccessive bool = true
 	var result R = typeTo[Range[P, R], R](this)
 	if len(others) == 0 {
@@ -221,66 +287,88 @@ func UnionOthers[P comparable, R any](this Range[P, R], others []R) (bool, R) {
 
 //Except函数求区间this与other差集，也就是this区间去掉other的剩余部分。
 //返回结果最多是两个区间，r1和r2。
-//当other是this的真子集时，差集分为两段，r1和r2都是非零值区间。
-//如果两个集合有部分交集，或者完全不相交，只有一个差集，结果r1是二者的差集，此时r2为零值区间。
-//如果两个区间相等，或者this是other的真子集，则差集是零值区间，因而，结果区间r1和r2都是零值区间。
-func Except[P comparable, R any](this, other Range[P, R]) (r1, r2 R) {
-	//r1,r2都是空值
-	// 如果二者不相交，则，range1区间是自身，second是零值区间
+//当other是this的真子集时，差集分为两段，r1和r2都不是空区间。
+//如果两个集合有部分交集，或者完全不相交，只有一个差集，结果r1是二者的差集，此时r2为空区间。
+//如果两个区间相等，或者this是other的真子集，则差集是空区间，因而，结果区间r1和r2都是空区间。
+//差集的端点来自other时，其边界类型与other相应端点的边界类型相反，比如，[1,5]去掉[2,3)，差集是[1,2)与[3,5]。
+func Except[P any, R any](this, other Range[P, R]) (r1, r2 R) {
+	r1, r2 = emptyOf(this), emptyOf(this)
+	// 如果二者不相交，则，range1区间是自身，second是空区间
+	thisLower, thisUpper := endpoints(this)
 	if !IsIntersected(this, other) {
-		r1 = this.Range(this.DeRange())
-		return
-	}
-	//如果相等或被other所完全包含,则头尾都是零值
-	if Equal(this, other) || IsIncluded(other, this) {
+		if !IsEmpty(this) {
+			r1 = withEndpoints(this, thisLower, thisUpper)
+		}
 		return
 	}
-	thisStart, thisEnd := this.DeRange()
-	otherStart, otherEnd := other.DeRange()
-	//如果完全包含了对方，且没有端点重合时，则first区间是以this的开始为开始，以other开始为结束。
-	//second区间则是以other的结束为开始，this的结束为结束。
-	if IsIncluded(this, other) && thisStart != otherStart && thisEnd != otherEnd {
-		r1 = this.Range(thisStart, otherStart)
-		r2 = this.Range(otherEnd, thisEnd)
-		return
-		//完全包含对方，起点重合，则r1是二者终点构成的区间
-	} else if IsIncluded(this, other) && thisStart == otherStart {
-		r1 = this.Range(otherEnd, thisEnd)
-		return
-		//完全包含对方，终点重合，则r1是二者起点构成的区间
-	} else if IsIncluded(this, other) && thisEnd == otherEnd {
-		r1 = this.Range(thisStart, otherStart)
-		return
+	otherLower, otherUpper := endpoints(other)
+	//first区间是this中在other之前的部分，以this的开始为开始，以other开始为结束。
+	//second区间是this中在other之后的部分，以other的结束为开始，this的结束为结束。
+	//other的起点（终点）无界时，this中不存在在other之前（之后）的部分。
+	var pieces []R
+	firstUpper := endpoint[P]{otherLower.value, otherLower.bound.flip()}
+	if otherLower.bound != Unbounded && !isEmptySpan(this, thisLower, firstUpper) {
+		pieces = append(pieces, withEndpoints(this, thisLower, firstUpper))
 	}
-	//如果只包含对方一个点，则分两种情况
-	//如果other只有起点在this之内，则r1区间是二者起点构成的区间。
-	if this.IsIncludedPoint(otherStart) {
-		r1 = this.Range(thisStart, otherStart)
-		return
+	secondLower := endpoint[P]{otherUpper.value, otherUpper.bound.flip()}
+	if otherUpper.bound != Unbounded && !isEmptySpan(this, secondLower, thisUpper) {
+		pieces = append(pieces, withEndpoints(this, secondLower, thisUpper))
 	}
-	//如果ohter只有终点在this之内，则r1区间是二者的终点构成。
-	if this.IsIncludedPoint(otherEnd) {
-		r1 = this.Range(otherEnd, thisEnd)
-		return
+	//如果相等或被other所完全包含,则头尾都是空区间
+	switch len(pieces) {
+	case 2:
+		r1, r2 = pieces[0], pieces[1]
+	case 1:
+		r1 = pieces[0]
 	}
 	return
 }
 
-//IsBefore函数判断this区间是否在另一个区间(other)之前，也就是this区间的终点是否在other区间之前。
-func IsBefore[P comparable, R any](this, other Range[P, R]) bool {
-	otherStart, _ := other.DeRange()
-	return this.IsBeforePoint(otherStart)
+//ExceptOthers函数求区间this去掉另一些区间（others）之后的差集，返回按顺序排列的、非空的剩余区间，
+//比如，[0,10)去掉[2,3)、[7,8]、[1,2]与(9,12)，剩余[0,1)、[3,7)与(8,9]。
+//others可以是无序的，也可以相交或者重叠，空区间会被忽略。others中没有区间时，结果是this本身；this是空区间或者被完全去掉时，结果是nil。
+//与连续调用Except方法不同，ExceptOthers先对others进行排序与合并，然后一次扫描得到全部剩余区间，时间复杂度是O(n log n)。
+func ExceptOthers[P any, R any](this Range[P, R], others []R) []R {
+	if IsEmpty(this) {
+		return nil
+	}
+	return exceptSorted(this, normalize[P, R](others))
 }
 
-//IsAfter函数计算this区间是否在另一个区间（other）之后，也就是this区间的起点是否在other区间之后。
-func IsAfter[P comparable, R any](this, other Range[P, R]) bool {
-	_, otherEnd := other.DeRange()
-	return this.IsAfterPoint(otherEnd)
+//IsBefore函数判断this区间是否在另一个区间(other)之前，也就是this区间的终点是否在other区间的起点之前。
+//二者的端点相同时，只要有一个端点是开边界，this区间就在other区间之前。
+func IsBefore[P any, R any](this, other Range[P, R]) bool {
+	_, thisUpper := endpoints(this)
+	otherLower, _ := endpoints(other)
+	return isEmptySpan(this, otherLower, thisUpper)
+}
+
+//IsAfter函数计算this区间是否在另一个区间（other）之后，也就是this区间的起点是否在other区间的终点之后。
+func IsAfter[P any, R any](this, other Range[P, R]) bool {
+	return IsBefore(other, this)
 }
 
 //RngToStr函数用来辅助将区间r按照固有格式[startStr,endStr）转换为字符串。这里，输入参数中的f函数
-//负责将类型P的值转换为string。
-func RngToStr[P comparable, R any](r Range[P, R], f func(P) string) string {
+//负责将类型P的值转换为string。括号的形式由起点与终点的边界类型决定，比如，[startStr,endStr]、(startStr,endStr)，
+//无界的起点与终点分别表示为-∞与+∞，比如，[startStr,+∞)、(-∞,endStr)，而空区间则表示为“empty”。
+func RngToStr[P any, R any](r Range[P, R], f func(P) string) string {
+	if IsEmpty(r) {
+		return EmptyRangeString
+	}
 	start, end := r.DeRange()
-	return "[" + f(start) + "," + f(end) + ")"
+	left, right := r.Bounds()
+	leftStr, rightStr := "["+f(start), f(end)+")"
+	switch left {
+	case Open:
+		leftStr = "(" + f(start)
+	case Unbounded:
+		leftStr = "(" + NegativeInfinity
+	}
+	switch right {
+	case Closed:
+		rightStr = f(end) + "]"
+	case Unbounded:
+		rightStr = PositiveInfinity + ")"
+	}
+	return leftStr + "," + rightStr
 }
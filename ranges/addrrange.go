@@ -0,0 +1,320 @@
+package ranges
+
+import (
+	"net/netip"
+	"strings"
+)
+
+//CreateAddrRange函数用给定的两个IP地址创建一个左闭右开区间，
+//无论两个地址的大小顺序如何，创建出来的区间的起点都会小于终点。两个地址的协议族（IPv4或者IPv6）不同，或者有一个地址无效时，结果是空区间。
+func CreateAddrRange(a1, a2 netip.Addr) AddrRange {
+	return CreateAddrRangeWithBounds(a1, a2, Closed, Open)
+}
+
+//CreateAddrRangeWithBounds函数用给定的两个IP地址及其边界类型创建一个区间，并将其化为规范形式，
+//left是a1的边界类型，right是a2的边界类型。如果a1大于a2，两个地址连同其边界类型一起交换。
+//比如，[10.0.0.1,10.0.0.9]的规范形式是[10.0.0.1,10.0.0.10)。地址的IPv6区域（zone）被忽略。
+func CreateAddrRangeWithBounds(a1, a2 netip.Addr, left, right BoundType) AddrRange {
+	if left != Unbounded && right != Unbounded && a2.Less(a1) {
+		a1, a2, left, right = a2, a1, right, left
+	}
+	return canonicalAddrRange(a1, a2, left, right)
+}
+
+//CreatePrefixAddrRange函数创建CIDR前缀p所包含的所有地址构成的区间，比如，10.0.0.0/24是[10.0.0.0,10.0.1.0)。
+//p的主机位被忽略，比如，10.0.0.1/24与10.0.0.0/24相同。
+func CreatePrefixAddrRange(p netip.Prefix) AddrRange {
+	p = p.Masked()
+	return CreateAddrRangeWithBounds(p.Addr(), lastAddrOf(p), Closed, Closed)
+}
+
+//CreateFamilyAddrRange函数创建地址a所在协议族的全部地址构成的区间，也就是0.0.0.0/0或者::/0。
+func CreateFamilyAddrRange(a netip.Addr) AddrRange {
+	return CreateAddrRangeWithBounds(a, a, Unbounded, Unbounded)
+}
+
+//ParseAddrRange函数解析IP地址区间的文本，支持以下几种形式：
+//    10.0.0.1-10.0.0.9     首尾两个地址（都包含在区间之中），“-”两侧可以有空白字符
+//    10.0.0.0/24           CIDR前缀
+//    10.0.0.1              单个地址
+//    [10.0.0.1,10.0.0.10)  区间的文本形式，参见ParseRange
+func ParseAddrRange(s string) (AddrRange, error) {
+	text := strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(text, "[") || strings.HasPrefix(text, "(") || strings.EqualFold(text, EmptyRangeString):
+		var proto AddrRange
+		rt, err := parseRangeText(text, parseAddr)
+		if err != nil {
+			return proto, err
+		}
+		if !rt.empty && rt.lower.bound != Unbounded && rt.upper.bound != Unbounded && rt.lower.value.Is4() != rt.upper.value.Is4() {
+			return proto, &ParseError{Text: s, Msg: "addresses are in different families"}
+		}
+		if err = checkRangeText[netip.Addr, AddrRange](proto, rt, text); err != nil {
+			return proto, err
+		}
+		return fromRangeText[netip.Addr, AddrRange](proto, rt), nil
+	case strings.Contains(text, "/"):
+		p, err := netip.ParsePrefix(text)
+		if err != nil {
+			return AddrRange{}, &ParseError{Text: s, Msg: "invalid prefix", Err: err}
+		}
+		return CreatePrefixAddrRange(p), nil
+	}
+	first, last := text, text
+	if i := strings.Index(text, "-"); i >= 0 {
+		first, last = strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:])
+	}
+	a1, err := netip.ParseAddr(first)
+	if err != nil {
+		return AddrRange{}, &ParseError{Text: s, Msg: "invalid address", Err: err}
+	}
+	a2, err := netip.ParseAddr(last)
+	if err != nil {
+		return AddrRange{}, &ParseError{Text: s, Msg: "invalid address", Err: err}
+	}
+	if a1.Is4() != a2.Is4() {
+		return AddrRange{}, &ParseError{Text: s, Msg: "addresses are in different families"}
+	}
+	return CreateAddrRangeWithBounds(a1, a2, Closed, Closed), nil
+}
+
+//AddrRange定义了IP地址区间，该类型的区间满足Range[netip.Addr, AddrRange]接口。
+//与IntRange相同，AddrRange是离散的，总是保持规范形式：起点是闭边界，终点是开边界，
+//所在协议族的最小地址被用作无界的起点，而协议族的最大地址（255.255.255.255或者ffff:...:ffff）没有下一个地址，
+//包含它的区间的终点是该地址本身并且是闭边界，比如，[255.255.255.0,255.255.255.255]，无界的终点也化为这种形式，
+//因而，10.0.0.0/25与10.0.0.128/25这样在地址上相邻的区间可以被合并为10.0.0.0/24。
+//AddrRange的区间不会具有无界的端点，所以，另一个协议族的地址总是在区间之前或者之后。
+//一个区间中的地址都属于同一个协议族，IPv4地址与IPv6地址是互不相关的，IPv4区间不包含任何IPv6地址（包括IPv4映射的IPv6地址），
+//反之亦然。因此，不应将两个协议族的区间放在同一个RangeSet中，需要同时处理两个协议族时，使用AddrSet。
+//零值的AddrRange是空区间。
+type AddrRange struct {
+	start  netip.Addr
+	end    netip.Addr
+	bounds boundPair //起点与终点的边界类型，起点总是闭边界，终点是开边界，或者终点是协议族的最大地址时是闭边界
+}
+
+func (ar AddrRange) Range(start, end netip.Addr) AddrRange {
+	return CreateAddrRange(start, end)
+}
+
+func (ar AddrRange) RangeWithBounds(start, end netip.Addr, left, right BoundType) AddrRange {
+	return CreateAddrRangeWithBounds(start, end, left, right)
+}
+
+func (ar AddrRange) DeRange() (start, end netip.Addr) {
+	return ar.start, ar.end
+}
+
+func (ar AddrRange) Bounds() (left, right BoundType) {
+	return ar.bounds.types()
+}
+
+func (ar AddrRange) IsIncludedPoint(a netip.Addr) bool {
+	return ar.start.IsValid() && !ar.IsAfterPoint(a) && !ar.IsBeforePoint(a)
+}
+func (ar AddrRange) IsBeforePoint(a netip.Addr) bool {
+	a = a.WithZone("")
+	if ar.bounds.rightClosed {
+		return ar.end.Less(a)
+	}
+	return !a.Less(ar.end)
+}
+func (ar AddrRange) IsAfterPoint(a netip.Addr) bool {
+	return a.WithZone("").Less(ar.start)
+}
+
+//Is4方法判断区间是否是IPv4地址区间。
+func (ar AddrRange) Is4() bool {
+	return ar.start.Is4()
+}
+
+//Is6方法判断区间是否是IPv6地址区间。
+func (ar AddrRange) Is6() bool {
+	return ar.start.Is6()
+}
+
+//First方法返回区间中的第一个地址，空区间返回false。
+func (ar AddrRange) First() (bool, netip.Addr) {
+	if ar.IsEmpty() {
+		return false, netip.Addr{}
+	}
+	return true, ar.start
+}
+
+//Last方法返回区间中的最后一个地址，空区间返回false。
+func (ar AddrRange) Last() (bool, netip.Addr) {
+	if ar.IsEmpty() {
+		return false, netip.Addr{}
+	}
+	if ar.bounds.rightClosed {
+		return true, ar.end
+	}
+	return true, ar.end.Prev()
+}
+
+//Prefixes方法将区间分解为最少的CIDR前缀，前缀按地址从小到大排列，比如，[10.0.0.1,10.0.0.9)分解为
+//10.0.0.1/32、10.0.0.2/31、10.0.0.4/30与10.0.0.8/32。空区间返回nil。
+func (ar AddrRange) Prefixes() []netip.Prefix {
+	ok, last := ar.Last()
+	if !ok {
+		return nil
+	}
+	var result []netip.Prefix
+	for first := ar.start; ; {
+		p := largestPrefixOf(first, last)
+		result = append(result, p)
+		end := lastAddrOf(p)
+		if end == last {
+			return result
+		}
+		first = end.Next()
+	}
+}
+
+////////////////////////////////////////////////////////////////////
+func (ar AddrRange) IsEmpty() bool {
+	return IsEmpty[netip.Addr, AddrRange](ar)
+}
+
+//IsPoint方法判断区间是否只包含一个地址，比如，10.0.0.1/32。
+func (ar AddrRange) IsPoint() bool {
+	ok, last := ar.Last()
+	return ok && last == ar.start
+}
+func (ar AddrRange) String() string {
+	return RngToStr[netip.Addr, AddrRange](ar, v2s[netip.Addr])
+}
+func (ar AddrRange) Equal(other AddrRange) bool {
+	return Equal[netip.Addr, AddrRange](ar, other)
+}
+func (ar AddrRange) IsIntersected(other AddrRange) bool {
+	return IsIntersected[netip.Addr, AddrRange](ar, other)
+}
+func (ar AddrRange) Intersect(other AddrRange) (bool, AddrRange) {
+	return Intersect[netip.Addr, AddrRange](ar, other)
+}
+func (ar AddrRange) IntersectOthers(others []AddrRange) (bool, AddrRange) {
+	return IntersectOthers[netip.Addr, AddrRange](ar, others)
+}
+func (ar AddrRange) Union(other AddrRange) (bool, AddrRange) {
+	return Union[netip.Addr, AddrRange](ar, other)
+}
+func (ar AddrRange) UnionOthers(others []AddrRange) (bool, AddrRange) {
+	return UnionOthers[netip.Addr, AddrRange](ar, others)
+}
+func (ar AddrRange) Except(other AddrRange) (r1, r2 AddrRange) {
+	return Except[netip.Addr, AddrRange](ar, other)
+}
+func (ar AddrRange) ExceptOthers(others []AddrRange) []AddrRange {
+	return ExceptOthers[netip.Addr, AddrRange](ar, others)
+}
+func (ar AddrRange) IsBefore(other AddrRange) bool {
+	return IsBefore[netip.Addr, AddrRange](ar, other)
+}
+func (ar AddrRange) IsAfter(other AddrRange) bool {
+	return IsAfter[netip.Addr, AddrRange](ar, other)
+}
+
+//MarshalJSON方法将区间编码为JSON对象，比如，{"start":"10.0.0.0","end":"10.0.1.0"}，编码规则与NumberRange相同。
+func (ar AddrRange) MarshalJSON() ([]byte, error) {
+	return marshalRangeJSON[netip.Addr, AddrRange](ar)
+}
+
+//UnmarshalJSON方法将JSON数据解码为区间，并化为规范形式，解码规则与NumberRange相同。JSON数据为null时，区间保持不变。
+func (ar *AddrRange) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	result, err := unmarshalRangeJSON[netip.Addr, AddrRange](*ar, data)
+	if err != nil {
+		return err
+	}
+	*ar = result
+	return nil
+}
+
+//canonicalAddrRange函数将起点不大于终点的地址区间化为规范形式。
+//无界端点的值仍被用来确定区间的协议族，两个端点都无效时，结果是零值的空区间。
+//有界的端点是无效地址，或者与区间的协议族不同时，结果是该协议族的空区间。
+func canonicalAddrRange(start, end netip.Addr, left, right BoundType) AddrRange {
+	start, end = start.WithZone(""), end.WithZone("")
+	var family netip.Addr
+	switch {
+	case left != Unbounded && start.IsValid():
+		family = start
+	case right != Unbounded && end.IsValid():
+		family = end
+	case start.IsValid():
+		family = start
+	case end.IsValid():
+		family = end
+	default:
+		return AddrRange{}
+	}
+	min, max := addrLimitsOf(family)
+	if (left != Unbounded && (!start.IsValid() || start.Is4() != family.Is4())) ||
+		(right != Unbounded && (!end.IsValid() || end.Is4() != family.Is4())) {
+		return AddrRange{start: min, end: min}
+	}
+	switch left {
+	case Unbounded:
+		start = min
+	case Open:
+		if start == max {
+			return AddrRange{start: max, end: max}
+		}
+		start = start.Next()
+	}
+	switch right {
+	case Unbounded:
+		return AddrRange{start: start, end: max, bounds: makeBoundPair(Closed, Closed)}
+	case Closed:
+		if end == max {
+			return AddrRange{start: start, end: max, bounds: makeBoundPair(Closed, Closed)}
+		}
+		end = end.Next()
+	}
+	if end.Less(start) {
+		end = start
+	}
+	return AddrRange{start: start, end: end}
+}
+
+//addrLimitsOf函数返回地址a所在协议族的最小地址与最大地址。
+func addrLimitsOf(a netip.Addr) (min, max netip.Addr) {
+	if a.Is4() {
+		return netip.IPv4Unspecified(), netip.AddrFrom4([4]byte{255, 255, 255, 255})
+	}
+	var ones [16]byte
+	for i := range ones {
+		ones[i] = 0xff
+	}
+	return netip.IPv6Unspecified(), netip.AddrFrom16(ones)
+}
+
+//lastAddrOf函数返回CIDR前缀p所包含的最后一个地址，也就是主机位全部为1的地址。
+func lastAddrOf(p netip.Prefix) netip.Addr {
+	bytes := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(bytes)*8; i++ {
+		bytes[i/8] |= 0x80 >> (i % 8)
+	}
+	last, _ := netip.AddrFromSlice(bytes)
+	return last
+}
+
+//largestPrefixOf函数返回以地址first开始、最后一个地址不在last之后的最大的CIDR前缀。
+func largestPrefixOf(first, last netip.Addr) netip.Prefix {
+	for bits := 0; bits < first.BitLen(); bits++ {
+		p := netip.PrefixFrom(first, bits)
+		if p.Masked().Addr() == first && !last.Less(lastAddrOf(p)) {
+			return p
+		}
+	}
+	return netip.PrefixFrom(first, first.BitLen())
+}
+
+//parseAddr函数解析IP地址，供ParseRange使用。
+func parseAddr(s string) (netip.Addr, error) {
+	return netip.ParseAddr(s)
+}
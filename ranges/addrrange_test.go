@@ -0,0 +1,115 @@
+package ranges
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+func TestAddrRange(t *testing.T) {
+	addr := netip.MustParseAddr
+	ar := CreateAddrRangeWithBounds(addr("10.0.0.1"), addr("10.0.0.9"), Closed, Closed)
+	if ar.String() != "[10.0.0.1,10.0.0.10)" || !ar.Equal(CreateAddrRangeWithBounds(addr("10.0.0.10"), addr("10.0.0.0"), Open, Open)) {
+		t.Errorf("canonical form: got %s", ar.String())
+	}
+	if !ar.IsIncludedPoint(addr("10.0.0.9")) || ar.IsIncludedPoint(addr("10.0.0.10")) || ar.IsIncludedPoint(addr("::ffff:10.0.0.5")) {
+		t.Errorf("IsIncludedPoint: wrong result")
+	}
+	if r := CreatePrefixAddrRange(netip.MustParsePrefix("10.0.0.1/24")); r.String() != "[10.0.0.0,10.0.1.0)" {
+		t.Errorf("CreatePrefixAddrRange: got %s", r.String())
+	}
+	top := CreatePrefixAddrRange(netip.MustParsePrefix("255.255.255.0/24"))
+	if ok, last := top.Last(); top.String() != "[255.255.255.0,255.255.255.255]" || !ok || last != addr("255.255.255.255") {
+		t.Errorf("range including the maximum address: got %s", top.String())
+	}
+	if all := CreateFamilyAddrRange(addr("::1")); !all.Is6() || !all.IsIncludedPoint(addr("ffff::")) || all.IsIncludedPoint(addr("1.2.3.4")) {
+		t.Errorf("CreateFamilyAddrRange: got %s", all.String())
+	}
+	if !CreateAddrRange(addr("10.0.0.1"), addr("::1")).IsEmpty() || !(AddrRange{}).IsEmpty() {
+		t.Errorf("ranges across families should be empty")
+	}
+	if r := CreateAddrRange(netip.Addr{}, addr("::1")); !r.IsEmpty() || r.IsIncludedPoint(addr("::")) {
+		t.Errorf("a range with an invalid endpoint should be empty, got %s", r.String())
+	}
+	//另一个协议族的地址总是在区间之前或者之后，即使区间的终点是无界的
+	v4, v6 := CreateFamilyAddrRange(addr("10.0.0.1")), CreatePrefixAddrRange(netip.MustParsePrefix("2001::/16"))
+	if ok, r := v4.Intersect(v6); ok || !r.IsEmpty() {
+		t.Errorf("%s ∩ %s = %s, %v", v4.String(), v6.String(), r.String(), ok)
+	}
+	if ok, r := CreateFamilyAddrRange(addr("::1")).Intersect(top); ok || !r.IsEmpty() {
+		t.Errorf("::/0 ∩ %s = %s, %v", top.String(), r.String(), ok)
+	}
+	if v4.IsIntersected(v6) || !v4.IsBefore(v6) || !v6.IsAfter(top) || !v4.IsBeforePoint(addr("::1")) || v4.IsIncludedPoint(addr("::1")) {
+		t.Errorf("ranges across families: wrong result")
+	}
+	if r1, _ := v4.Except(v6); !r1.Equal(v4) {
+		t.Errorf("%s - %s = %s", v4.String(), v6.String(), r1.String())
+	}
+	lower, upper := CreatePrefixAddrRange(netip.MustParsePrefix("10.0.0.0/25")), CreatePrefixAddrRange(netip.MustParsePrefix("10.0.0.128/25"))
+	if ok, u := lower.Union(upper); !ok || u.String() != "[10.0.0.0,10.0.1.0)" {
+		t.Errorf("adjacent prefixes should merge, got %s", u.String())
+	}
+
+	var decoded AddrRange
+	data, _ := json.Marshal(top)
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Equal(top) || string(data) != `{"start":"255.255.255.0","end":"255.255.255.255","bounds":"[]"}` {
+		t.Errorf("JSON round trip: got %s from %s, %v", decoded.String(), data, err)
+	}
+}
+
+func TestParseAddrRange(t *testing.T) {
+	cases := map[string]string{
+		"10.0.0.1-10.0.0.9":     "[10.0.0.1,10.0.0.10)",
+		" 10.0.0.9 - 10.0.0.1 ": "[10.0.0.1,10.0.0.10)",
+		"10.0.0.0/8":            "[10.0.0.0,11.0.0.0)",
+		"2001:db8::/32":         "[2001:db8::,2001:db9::)",
+		"fe80::1%eth0":          "[fe80::1,fe80::2)",
+		"[10.0.0.1,10.0.0.10]":  "[10.0.0.1,10.0.0.11)",
+		"[10.0.0.1,+∞)":         "[10.0.0.1,255.255.255.255]",
+	}
+	for text, want := range cases {
+		if r, err := ParseAddrRange(text); err != nil || r.String() != want {
+			t.Errorf("ParseAddrRange(%q) = %s, %v", text, r.String(), err)
+		}
+	}
+	for _, text := range []string{"10.0.0.1-::1", "[10.0.0.1,::1)", "(::1,10.0.0.1]", "10.0.0.0/33", "10.0.0", "[10.0.0.1,x)"} {
+		if r, err := ParseAddrRange(text); err == nil {
+			t.Errorf("ParseAddrRange(%q) should fail, got %s", text, r.String())
+		}
+	}
+}
+
+func TestAddrRangePrefixes(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"10.0.0.1-10.0.0.8", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/32"}},
+		{"10.0.0.0-10.0.1.255", []string{"10.0.0.0/23"}},
+		{"0.0.0.0-255.255.255.255", []string{"0.0.0.0/0"}},
+		{"255.255.255.254-255.255.255.255", []string{"255.255.255.254/31"}},
+		{"::-::ffff", []string{"::/112"}},
+		{"2001:db8::1-2001:db8::2", []string{"2001:db8::1/128", "2001:db8::2/128"}},
+		{"::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"::/0"}},
+	}
+	for _, c := range cases {
+		r, err := ParseAddrRange(c.text)
+		if err != nil {
+			t.Fatalf("ParseAddrRange(%q): %v", c.text, err)
+		}
+		got := r.Prefixes()
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %v, want %v", c.text, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i].String() != c.want[i] {
+				t.Errorf("%s: got %v, want %v", c.text, got, c.want)
+				break
+			}
+		}
+	}
+	if got := (AddrRange{}).Prefixes(); got != nil {
+		t.Errorf("Prefixes of an empty range: got %v", got)
+	}
+}
@@ -0,0 +1,174 @@
+package ranges
+
+import (
+	"net/netip"
+	"strings"
+)
+
+//AddrSet定义了IP地址集合，比如，防火墙规则的允许列表与拒绝列表。
+//AddrSet分别使用两个RangeSet保存IPv4地址区间与IPv6地址区间，两个协议族互不影响，
+//因而可以放心地混合使用两个协议族的地址区间，集合运算与RangeSet相同，比如，允许列表去掉拒绝列表：
+//    effective := allow.Except(deny)
+//IPv4映射的IPv6地址（::ffff:0:0/96，比如，::ffff:10.1.2.3）被当作对应的IPv4地址，
+//因而，拒绝10.1.2.3同样拒绝::ffff:10.1.2.3，而::/0这样覆盖映射地址段的区间也包含了相应的IPv4地址。
+//与RangeSet相同，AddrSet是不可变的值类型，所有运算都返回新的集合。零值的AddrSet是空集。
+type AddrSet struct {
+	v4 RangeSet[netip.Addr, AddrRange]
+	v6 RangeSet[netip.Addr, AddrRange]
+}
+
+//NewAddrSet函数用给定的一些地址区间（rs）创建一个地址集合，给定的区间可以是无序的，可以彼此相交或相邻，也可以属于不同的协议族。
+func NewAddrSet(rs ...AddrRange) AddrSet {
+	var v4, v6 []AddrRange
+	for _, r := range rs {
+		switch {
+		case r.IsEmpty():
+		case r.Is4():
+			v4 = append(v4, r)
+		default:
+			if ok, mapped := r.Intersect(mappedAddrRange); ok {
+				v4 = append(v4, unmapAddrRange(mapped))
+				r1, r2 := r.Except(mappedAddrRange)
+				v6 = append(v6, r1, r2)
+			} else {
+				v6 = append(v6, r)
+			}
+		}
+	}
+	return AddrSet{v4: NewRangeSet[netip.Addr, AddrRange](v4...), v6: NewRangeSet[netip.Addr, AddrRange](v6...)}
+}
+
+//NewPrefixAddrSet函数用给定的一些CIDR前缀创建一个地址集合。
+func NewPrefixAddrSet(prefixes ...netip.Prefix) AddrSet {
+	rs := make([]AddrRange, len(prefixes))
+	for i, p := range prefixes {
+		rs[i] = CreatePrefixAddrRange(p)
+	}
+	return NewAddrSet(rs...)
+}
+
+//ParseAddrSet函数解析一组地址区间的文本，每个文本的形式参见ParseAddrRange，比如，
+//    ranges.ParseAddrSet("10.0.0.0/8", "192.168.1.10-192.168.1.20", "2001:db8::/32")
+func ParseAddrSet(texts ...string) (AddrSet, error) {
+	rs := make([]AddrRange, len(texts))
+	for i, s := range texts {
+		r, err := ParseAddrRange(s)
+		if err != nil {
+			return AddrSet{}, err
+		}
+		rs[i] = r
+	}
+	return NewAddrSet(rs...), nil
+}
+
+//IPv4方法返回集合中的IPv4地址区间构成的RangeSet。
+func (s AddrSet) IPv4() RangeSet[netip.Addr, AddrRange] {
+	return s.v4
+}
+
+//IPv6方法返回集合中的IPv6地址区间构成的RangeSet。
+func (s AddrSet) IPv6() RangeSet[netip.Addr, AddrRange] {
+	return s.v6
+}
+
+//Ranges方法返回集合中规范化之后的区间，IPv4地址区间在前，IPv6地址区间在后。
+func (s AddrSet) Ranges() []AddrRange {
+	return append(s.v4.Ranges(), s.v6.ranges...)
+}
+
+//Prefixes方法将集合分解为最少的CIDR前缀，IPv4前缀在前，IPv6前缀在后，各自按地址从小到大排列。
+func (s AddrSet) Prefixes() []netip.Prefix {
+	var result []netip.Prefix
+	for _, r := range s.Ranges() {
+		result = append(result, r.Prefixes()...)
+	}
+	return result
+}
+
+//IsEmpty方法判断集合是否为空集。
+func (s AddrSet) IsEmpty() bool {
+	return s.v4.IsEmpty() && s.v6.IsEmpty()
+}
+
+//Add方法返回在集合中加入区间r之后的新集合。
+func (s AddrSet) Add(r AddrRange) AddrSet {
+	return s.Union(NewAddrSet(r))
+}
+
+//AddPrefix方法返回在集合中加入CIDR前缀p之后的新集合。
+func (s AddrSet) AddPrefix(p netip.Prefix) AddrSet {
+	return s.Add(CreatePrefixAddrRange(p))
+}
+
+//Remove方法返回在集合中去掉区间r之后的新集合。
+func (s AddrSet) Remove(r AddrRange) AddrSet {
+	return s.Except(NewAddrSet(r))
+}
+
+//RemovePrefix方法返回在集合中去掉CIDR前缀p之后的新集合。
+func (s AddrSet) RemovePrefix(p netip.Prefix) AddrSet {
+	return s.Remove(CreatePrefixAddrRange(p))
+}
+
+//Contains方法判断地址a是否属于集合，IPv4映射的IPv6地址按照对应的IPv4地址判断。
+func (s AddrSet) Contains(a netip.Addr) bool {
+	a = a.Unmap()
+	if a.Is4() {
+		return s.v4.Contains(a)
+	}
+	return s.v6.Contains(a)
+}
+
+//ContainsPrefix方法判断CIDR前缀p中的所有地址是否都属于集合，IPv4映射的IPv6地址按照对应的IPv4地址判断。
+func (s AddrSet) ContainsPrefix(p netip.Prefix) bool {
+	r := CreatePrefixAddrRange(p)
+	if r.Is4() {
+		return s.v4.ContainsRange(r)
+	}
+	if ok, mapped := r.Intersect(mappedAddrRange); ok {
+		r1, r2 := r.Except(mappedAddrRange)
+		return s.v4.ContainsRange(unmapAddrRange(mapped)) &&
+			(r1.IsEmpty() || s.v6.ContainsRange(r1)) && (r2.IsEmpty() || s.v6.ContainsRange(r2))
+	}
+	return s.v6.ContainsRange(r)
+}
+
+//Union方法求集合与另一个集合（other）的并集。
+func (s AddrSet) Union(other AddrSet) AddrSet {
+	return AddrSet{v4: s.v4.Union(other.v4), v6: s.v6.Union(other.v6)}
+}
+
+//Intersect方法求集合与另一个集合（other）的交集。
+func (s AddrSet) Intersect(other AddrSet) AddrSet {
+	return AddrSet{v4: s.v4.Intersect(other.v4), v6: s.v6.Intersect(other.v6)}
+}
+
+//Except方法求集合与另一个集合（other）的差集，比如，允许列表去掉拒绝列表。
+func (s AddrSet) Except(other AddrSet) AddrSet {
+	return AddrSet{v4: s.v4.Except(other.v4), v6: s.v6.Except(other.v6)}
+}
+
+//Equal方法判断集合是否与另一个集合（other）相等。
+func (s AddrSet) Equal(other AddrSet) bool {
+	return s.v4.Equal(other.v4) && s.v6.Equal(other.v6)
+}
+
+//String方法将集合化为CIDR前缀的列表，比如，{10.0.0.0/8,2001:db8::/32}。
+func (s AddrSet) String() string {
+	prefixes := s.Prefixes()
+	strs := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		strs[i] = p.String()
+	}
+	return "{" + strings.Join(strs, ",") + "}"
+}
+
+//mappedAddrRange是IPv4映射的IPv6地址段::ffff:0:0/96构成的区间。
+var mappedAddrRange = CreatePrefixAddrRange(netip.MustParsePrefix("::ffff:0:0/96"))
+
+//unmapAddrRange函数将IPv4映射的IPv6地址区间r化为对应的IPv4地址区间，r应在mappedAddrRange之中。
+func unmapAddrRange(r AddrRange) AddrRange {
+	_, first := r.First()
+	_, last := r.Last()
+	return CreateAddrRangeWithBounds(first.Unmap(), last.Unmap(), Closed, Closed)
+}
@@ -0,0 +1,65 @@
+package ranges
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestAddrSet(t *testing.T) {
+	allow, err := ParseAddrSet("10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32", "0.0.0.0/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allow.String() != "{0.0.0.0/1,192.168.1.0/24,2001:db8::/32}" || allow.IPv4().Len() != 2 || allow.IPv6().Len() != 1 {
+		t.Errorf("ParseAddrSet: got %s", allow.String())
+	}
+	deny, err := ParseAddrSet("10.1.2.3", "10.1.2.4-10.1.2.5", "2001:db8::/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	effective := allow.Except(deny)
+	addr := netip.MustParseAddr
+	for a, want := range map[string]bool{
+		"10.1.2.2": true, "10.1.2.3": false, "10.1.2.5": false, "10.1.2.6": true,
+		"192.168.1.7": true, "192.168.2.1": false, "200.0.0.1": false,
+		"2001:db8::1": false, "2001:db8:0:1::1": true, "::ffff:10.1.2.2": true,
+		"::ffff:10.1.2.3": false, "::ffff:200.0.0.1": false,
+	} {
+		if got := effective.Contains(addr(a)); got != want {
+			t.Errorf("Contains(%s) = %v", a, got)
+		}
+	}
+	if !effective.ContainsPrefix(netip.MustParsePrefix("10.1.3.0/24")) || effective.ContainsPrefix(netip.MustParsePrefix("10.1.2.0/24")) {
+		t.Errorf("ContainsPrefix: wrong result")
+	}
+	if got := effective.Intersect(NewPrefixAddrSet(netip.MustParsePrefix("10.1.2.0/29"))).String(); got != "{10.1.2.0/31,10.1.2.2/32,10.1.2.6/31}" {
+		t.Errorf("Intersect: got %s", got)
+	}
+	restored := effective.Union(deny)
+	if !restored.Equal(allow) {
+		t.Errorf("Union should restore the allow list, got %s", restored.String())
+	}
+	if s := NewAddrSet().AddPrefix(netip.MustParsePrefix("10.0.0.0/25")).AddPrefix(netip.MustParsePrefix("10.0.0.128/25")).String(); s != "{10.0.0.0/24}" {
+		t.Errorf("AddPrefix should merge adjacent prefixes, got %s", s)
+	}
+	if !allow.RemovePrefix(netip.MustParsePrefix("0.0.0.0/0")).RemovePrefix(netip.MustParsePrefix("::/0")).IsEmpty() {
+		t.Errorf("RemovePrefix: wrong result")
+	}
+
+	//拒绝IPv4地址同样拒绝对应的IPv4映射地址
+	dualStack, _ := ParseAddrSet("::/0")
+	dualStack = dualStack.Except(deny)
+	if dualStack.Contains(addr("::ffff:10.1.2.3")) || dualStack.Contains(addr("10.1.2.3")) ||
+		!dualStack.Contains(addr("::ffff:10.1.2.2")) || !dualStack.Contains(addr("2001:db9::1")) {
+		t.Errorf("IPv4-mapped addresses should be treated as IPv4 addresses, got %s", dualStack.String())
+	}
+	if mapped, _ := ParseAddrSet("::ffff:10.0.0.0/104"); !mapped.Equal(NewPrefixAddrSet(netip.MustParsePrefix("10.0.0.0/8"))) {
+		t.Errorf("NewAddrSet should unmap IPv4-mapped ranges, got %s", mapped.String())
+	}
+	if !effective.ContainsPrefix(netip.MustParsePrefix("::ffff:10.1.3.0/120")) || effective.ContainsPrefix(netip.MustParsePrefix("::ffff:10.1.2.0/120")) {
+		t.Errorf("ContainsPrefix: wrong result for IPv4-mapped prefixes")
+	}
+	if _, err := ParseAddrSet("10.0.0.0/8", "bad"); err == nil {
+		t.Errorf("ParseAddrSet should fail on an invalid range")
+	}
+}
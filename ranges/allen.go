@@ -0,0 +1,348 @@
+package ranges
+
+import "strings"
+
+//Relation定义了Allen区间代数中两个区间之间的关系。Allen区间代数共有13种基本关系，每种基本关系占用一个二进制位，
+//多个基本关系按位或（|）在一起，表示“可能是其中任意一种关系”，比如，Before|Meets表示“在之前或者紧邻在之前”。
+//基本关系的定义如下，其中，区间a与b都不是空区间，a与b的关系就是Relate(a,b)的返回值：
+//    Before        a在b之前，二者之间有空隙，比如，[1,2)与[3,4)
+//    Meets         a紧邻在b之前，二者既不相交也没有空隙，比如，[1,2)与[2,4)
+//    Overlaps      a的起点在b的起点之前，a的终点在b之内，比如，[1,3)与[2,4)
+//    FinishedBy    a的起点在b的起点之前，二者终点相同，比如，[1,4)与[2,4)
+//    Contains      b在a之内，并且二者的起点与终点都不相同，比如，[1,4)与[2,3)
+//    Starts        二者起点相同，a的终点在b的终点之前，比如，[1,2)与[1,4)
+//    Equals        二者相等，比如，[1,4)与[1,4)
+//    StartedBy     二者起点相同，a的终点在b的终点之后，与Starts相反
+//    During        a在b之内，并且二者的起点与终点都不相同，与Contains相反
+//    Finishes      a的起点在b的起点之后，二者终点相同，与FinishedBy相反
+//    OverlappedBy  与Overlaps相反
+//    MetBy         a紧邻在b之后，与Meets相反
+//    After         a在b之后，二者之间有空隙，与Before相反
+//判断起点与终点是否相同时，边界类型也必须相同，比如，[1,4)与[1,4]的关系是Starts，(2,4)与[2,4)的关系是Finishes。
+type Relation uint16
+
+const (
+	Before Relation = 1 << iota
+	Meets
+	Overlaps
+	FinishedBy
+	Contains
+	Starts
+	Equals
+	StartedBy
+	During
+	Finishes
+	OverlappedBy
+	MetBy
+	After
+)
+
+//AllRelations是所有13种基本关系按位或的结果，表示两个区间之间的关系完全不确定。
+const AllRelations Relation = 1<<relationCount - 1
+
+//relationCount是基本关系的个数。
+const relationCount = 13
+
+//relationNames是基本关系的名称，顺序与基本关系的二进制位相同。
+var relationNames = [relationCount]string{
+	"before", "meets", "overlaps", "finished-by", "contains", "starts", "equals",
+	"started-by", "during", "finishes", "overlapped-by", "met-by", "after",
+}
+
+//Relate函数计算区间a与区间b之间的Allen关系，返回值是13种基本关系之一。
+//空区间与任何区间之间都没有关系，如果a或者b是空区间，返回0。
+func Relate[P any, R any](a, b Range[P, R]) Relation {
+	if IsEmpty(a) || IsEmpty(b) {
+		return 0
+	}
+	if IsBefore(a, b) {
+		if isConnected(a, b) {
+			return Meets
+		}
+		return Before
+	}
+	if IsBefore(b, a) {
+		if isConnected(a, b) {
+			return MetBy
+		}
+		return After
+	}
+	aLower, aUpper := endpoints(a)
+	bLower, bUpper := endpoints(b)
+	cl, cu := compareLower(a, aLower, bLower), compareUpper(a, aUpper, bUpper)
+	switch {
+	case cl == 0 && cu == 0:
+		return Equals
+	case cl == 0:
+		if cu < 0 {
+			return Starts
+		}
+		return StartedBy
+	case cu == 0:
+		if cl > 0 {
+			return Finishes
+		}
+		return FinishedBy
+	case cl < 0 && cu > 0:
+		return Contains
+	case cl > 0 && cu < 0:
+		return During
+	case cl < 0:
+		return Overlaps
+	default:
+		return OverlappedBy
+	}
+}
+
+//In方法判断关系r是否是关系集合set中的一种，比如，Relate(a,b).In(Before|Meets)判断a是否在b之前或者紧邻在b之前。
+//r为0时返回false。
+func (r Relation) In(set Relation) bool {
+	return r != 0 && r&set == r
+}
+
+//Converse方法返回关系r的逆关系，也就是说，如果a与b的关系是r，那么b与a的关系就是r.Converse()。
+//r是多个基本关系按位或的结果时，返回每个基本关系的逆关系按位或的结果。
+func (r Relation) Converse() Relation {
+	var result Relation
+	for i := 0; i < relationCount; i++ {
+		if r&(1<<i) != 0 {
+			result |= 1 << (relationCount - 1 - i)
+		}
+	}
+	return result
+}
+
+//Compose方法计算关系r与关系other的复合关系，也就是说，如果a与b的关系是r，b与c的关系是other，
+//那么a与c的关系一定是Compose返回的基本关系之一。r或other是多个基本关系按位或的结果时，返回每对基本关系复合关系的并集。
+//复合关系使用Allen的复合表计算，它假设所有的区间都不是单个点，比如[a,a]。
+func (r Relation) Compose(other Relation) Relation {
+	var result Relation
+	for i := 0; i < relationCount; i++ {
+		if r&(1<<i) == 0 {
+			continue
+		}
+		for j := 0; j < relationCount; j++ {
+			if other&(1<<j) != 0 {
+				result |= compositionTable[i][j]
+			}
+		}
+	}
+	return result
+}
+
+//String方法返回关系的名称，多个基本关系之间用“|”分隔，比如“before|meets”，r为0时返回“none”。
+func (r Relation) String() string {
+	if r == 0 {
+		return "none"
+	}
+	var names []string
+	for i := 0; i < relationCount; i++ {
+		if r&(1<<i) != 0 {
+			names = append(names, relationNames[i])
+		}
+	}
+	if r&^AllRelations != 0 {
+		names = append(names, "Relation("+v2s(uint16(r&^AllRelations))+")")
+	}
+	return strings.Join(names, "|")
+}
+
+//compositionTable是Allen的复合表，compositionTable[i][j]是第i个基本关系与第j个基本关系的复合关系，
+//基本关系的顺序与其二进制位的顺序相同，每一行中的13项依次对应Before、Meets……After。
+var compositionTable = [relationCount][relationCount]Relation{
+	{ //Before
+		Before,
+		Before,
+		Before,
+		Before,
+		Before,
+		Before,
+		Before,
+		Before,
+		Before | Meets | Overlaps | Starts | During,
+		Before | Meets | Overlaps | Starts | During,
+		Before | Meets | Overlaps | Starts | During,
+		Before | Meets | Overlaps | Starts | During,
+		AllRelations,
+	},
+	{ //Meets
+		Before,
+		Before,
+		Before,
+		Before,
+		Before,
+		Meets,
+		Meets,
+		Meets,
+		Overlaps | Starts | During,
+		Overlaps | Starts | During,
+		Overlaps | Starts | During,
+		FinishedBy | Equals | Finishes,
+		Contains | StartedBy | OverlappedBy | MetBy | After,
+	},
+	{ //Overlaps
+		Before,
+		Before,
+		Before | Meets | Overlaps,
+		Before | Meets | Overlaps,
+		Before | Meets | Overlaps | FinishedBy | Contains,
+		Overlaps,
+		Overlaps,
+		Overlaps | FinishedBy | Contains,
+		Overlaps | Starts | During,
+		Overlaps | Starts | During,
+		Overlaps | FinishedBy | Contains | Starts | Equals | StartedBy | During | Finishes | OverlappedBy,
+		Contains | StartedBy | OverlappedBy,
+		Contains | StartedBy | OverlappedBy | MetBy | After,
+	},
+	{ //FinishedBy
+		Before,
+		Meets,
+		Overlaps,
+		FinishedBy,
+		Contains,
+		Overlaps,
+		FinishedBy,
+		Contains,
+		Overlaps | Starts | During,
+		FinishedBy | Equals | Finishes,
+		Contains | StartedBy | OverlappedBy,
+		Contains | StartedBy | OverlappedBy,
+		Contains | StartedBy | OverlappedBy | MetBy | After,
+	},
+	{ //Contains
+		Before | Meets | Overlaps | FinishedBy | Contains,
+		Overlaps | FinishedBy | Contains,
+		Overlaps | FinishedBy | Contains,
+		Contains,
+		Contains,
+		Overlaps | FinishedBy | Contains,
+		Contains,
+		Contains,
+		Overlaps | FinishedBy | Contains | Starts | Equals | StartedBy | During | Finishes | OverlappedBy,
+		Contains | StartedBy | OverlappedBy,
+		Contains | StartedBy | OverlappedBy,
+		Contains | StartedBy | OverlappedBy,
+		Contains | StartedBy | OverlappedBy | MetBy | After,
+	},
+	{ //Starts
+		Before,
+		Before,
+		Before | Meets | Overlaps,
+		Before | Meets | Overlaps,
+		Before | Meets | Overlaps | FinishedBy | Contains,
+		Starts,
+		Starts,
+		Starts | Equals | StartedBy,
+		During,
+		During,
+		During | Finishes | OverlappedBy,
+		MetBy,
+		After,
+	},
+	{ //Equals
+		Before,
+		Meets,
+		Overlaps,
+		FinishedBy,
+		Contains,
+		Starts,
+		Equals,
+		StartedBy,
+		During,
+		Finishes,
+		OverlappedBy,
+		MetBy,
+		After,
+	},
+	{ //StartedBy
+		Before | Meets | Overlaps | FinishedBy | Contains,
+		Overlaps | FinishedBy | Contains,
+		Overlaps | FinishedBy | Contains,
+		Contains,
+		Contains,
+		Starts | Equals | StartedBy,
+		StartedBy,
+		StartedBy,
+		During | Finishes | OverlappedBy,
+		OverlappedBy,
+		OverlappedBy,
+		MetBy,
+		After,
+	},
+	{ //During
+		Before,
+		Before,
+		Before | Meets | Overlaps | Starts | During,
+		Before | Meets | Overlaps | Starts | During,
+		AllRelations,
+		During,
+		During,
+		During | Finishes | OverlappedBy | MetBy | After,
+		During,
+		During,
+		During | Finishes | OverlappedBy | MetBy | After,
+		After,
+		After,
+	},
+	{ //Finishes
+		Before,
+		Meets,
+		Overlaps | Starts | During,
+		FinishedBy | Equals | Finishes,
+		Contains | StartedBy | OverlappedBy | MetBy | After,
+		During,
+		Finishes,
+		OverlappedBy | MetBy | After,
+		During,
+		Finishes,
+		OverlappedBy | MetBy | After,
+		After,
+		After,
+	},
+	{ //OverlappedBy
+		Before | Meets | Overlaps | FinishedBy | Contains,
+		Overlaps | FinishedBy | Contains,
+		Overlaps | FinishedBy | Contains | Starts | Equals | StartedBy | During | Finishes | OverlappedBy,
+		Contains | StartedBy | OverlappedBy,
+		Contains | StartedBy | OverlappedBy | MetBy | After,
+		During | Finishes | OverlappedBy,
+		OverlappedBy,
+		OverlappedBy | MetBy | After,
+		During | Finishes | OverlappedBy,
+		OverlappedBy,
+		OverlappedBy | MetBy | After,
+		After,
+		After,
+	},
+	{ //MetBy
+		Before | Meets | Overlaps | FinishedBy | Contains,
+		Starts | Equals | StartedBy,
+		During | Finishes | OverlappedBy,
+		MetBy,
+		After,
+		During | Finishes | OverlappedBy,
+		MetBy,
+		After,
+		During | Finishes | OverlappedBy,
+		MetBy,
+		After,
+		After,
+		After,
+	},
+	{ //After
+		AllRelations,
+		During | Finishes | OverlappedBy | MetBy | After,
+		During | Finishes | OverlappedBy | MetBy | After,
+		After,
+		After,
+		During | Finishes | OverlappedBy | MetBy | After,
+		After,
+		After,
+		During | Finishes | OverlappedBy | MetBy | After,
+		After,
+		After,
+		After,
+		After,
+	},
+}
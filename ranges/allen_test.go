@@ -0,0 +1,97 @@
+package ranges
+
+import "testing"
+
+func TestRelate(t *testing.T) {
+	nr := CreateNumberRangeWithBounds[int]
+	relate := Relate[int, NumberRange[int]]
+	cases := []struct {
+		a, b NumberRange[int]
+		want Relation
+	}{
+		{CreateNumberRange(1, 2), CreateNumberRange(3, 4), Before},
+		{CreateNumberRange(1, 2), CreateNumberRange(2, 4), Meets},
+		{nr(1, 2, Closed, Closed), nr(2, 4, Open, Open), Meets},
+		{CreateNumberRange(1, 2), nr(2, 4, Open, Open), Before},
+		{nr(1, 2, Closed, Closed), CreateNumberRange(2, 4), Overlaps},
+		{CreateNumberRange(1, 3), CreateNumberRange(2, 4), Overlaps},
+		{CreateNumberRange(1, 4), CreateNumberRange(2, 4), FinishedBy},
+		{CreateNumberRange(1, 4), CreateNumberRange(2, 3), Contains},
+		{CreateNumberRange(1, 2), CreateNumberRange(1, 4), Starts},
+		{CreateNumberRange(1, 4), CreateNumberRange(1, 4), Equals},
+		{CreateNumberRange(1, 4), nr(1, 4, Closed, Closed), Starts},
+		{nr(2, 4, Open, Open), CreateNumberRange(2, 4), Finishes},
+		{CreateNumberRangeFrom(1, Closed), CreateNumberRange(2, 3), Contains},
+		{CreateUnboundedNumberRange[int](), CreateNumberRangeTo(3, Open), StartedBy},
+		{CreateNumberRange(3, 4), CreateNumberRange(1, 2), After},
+		{CreateNumberRange(2, 4), CreateNumberRange(1, 2), MetBy},
+		{CreateNumberRange(2, 2), CreateNumberRange(1, 2), 0},
+	}
+	for _, c := range cases {
+		if got := relate(c.a, c.b); got != c.want {
+			t.Errorf("Relate(%s,%s) = %s, want %s", c.a.String(), c.b.String(), got, c.want)
+		}
+		if got := relate(c.b, c.a); got != c.want.Converse() {
+			t.Errorf("Relate(%s,%s) = %s, want %s", c.b.String(), c.a.String(), got, c.want.Converse())
+		}
+	}
+	if !relate(CreateNumberRange(1, 2), CreateNumberRange(2, 4)).In(Before|Meets) || relate(CreateNumberRange(1, 3), CreateNumberRange(2, 4)).In(Before|Meets) {
+		t.Errorf("In: wrong result")
+	}
+	if Relation(0).In(AllRelations) {
+		t.Errorf("no relation should not be in any set")
+	}
+	if s := (Before | Meets | After).String(); s != "before|meets|after" {
+		t.Errorf("String: got %s", s)
+	}
+}
+
+func TestRelationComposition(t *testing.T) {
+	if Before.Compose(After) != AllRelations || Meets.Compose(MetBy) != FinishedBy|Equals|Finishes {
+		t.Errorf("Compose: got %s and %s", Before.Compose(After), Meets.Compose(MetBy))
+	}
+	if (Before | Meets).Compose(Before) != Before {
+		t.Errorf("Compose: got %s", (Before | Meets).Compose(Before))
+	}
+	if (Starts | During).Converse() != StartedBy|Contains {
+		t.Errorf("Converse: got %s", (Starts | During).Converse())
+	}
+	//用小的整数区间验证复合表：对于任意三个区间a、b、c，a与c的关系一定在复合关系之中，
+	//并且复合关系中的每一个基本关系都能由某三个区间得到
+	var rs []NumberRange[int]
+	for start := 0; start < 7; start++ {
+		for end := start + 1; end < 7; end++ {
+			rs = append(rs, CreateNumberRange(start, end))
+		}
+	}
+	relate := Relate[int, NumberRange[int]]
+	var seen [relationCount][relationCount]Relation
+	for _, a := range rs {
+		for _, b := range rs {
+			for _, c := range rs {
+				ab, bc, ac := relate(a, b), relate(b, c), relate(a, c)
+				if !ac.In(ab.Compose(bc)) {
+					t.Fatalf("%s;%s gives %s, not in %s", ab, bc, ac, ab.Compose(bc))
+				}
+				for i := 0; i < relationCount; i++ {
+					for j := 0; j < relationCount; j++ {
+						if ab == 1<<i && bc == 1<<j {
+							seen[i][j] |= ac
+						}
+					}
+				}
+			}
+		}
+	}
+	if seen != compositionTable {
+		t.Errorf("composition table has relations that never occur")
+	}
+	for i := 0; i < relationCount; i++ {
+		for j := 0; j < relationCount; j++ {
+			r1, r2 := Relation(1<<i), Relation(1<<j)
+			if r1.Compose(r2).Converse() != r2.Converse().Compose(r1.Converse()) {
+				t.Errorf("converse of %s;%s is not symmetric", r1, r2)
+			}
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package ranges
+
+//BoundType定义了区间端点的边界类型，用来表示端点本身是否属于区间，或者区间在该方向上是否无界。
+//区间的左右两个端点各自具有边界类型，因而可以表示如下几类区间：
+//    [start,end)  左闭右开区间，这是ranges包缺省的区间形式
+//    [start,end]  闭区间
+//    (start,end]  左开右闭区间
+//    (start,end)  开区间
+//    [start,+∞)   左端点有界、右端点无界的区间，类似地，还有(-∞,end)、(-∞,+∞)等。
+type BoundType uint8
+
+const (
+	//Closed表示闭边界，端点属于区间，左端点用“[”表示，右端点用“]”表示。
+	Closed BoundType = iota
+	//Open表示开边界，端点不属于区间，左端点用“(”表示，右端点用“)”表示。
+	Open
+	//Unbounded表示无界，左端点为负无穷（-∞），右端点为正无穷（+∞），此时端点的值没有意义。
+	Unbounded
+)
+
+//NegativeInfinity与PositiveInfinity是区间化为字符串时，无界的起点与终点的表示形式，
+//EmptyRangeString是空区间化为字符串时的表示形式。
+const (
+	NegativeInfinity = "-∞"
+	PositiveInfinity = "+∞"
+	EmptyRangeString = "empty"
+)
+
+//String方法返回边界类型的名称。
+func (bt BoundType) String() string {
+	switch bt {
+	case Closed:
+		return "Closed"
+	case Open:
+		return "Open"
+	case Unbounded:
+		return "Unbounded"
+	default:
+		return "BoundType(" + v2s(uint8(bt)) + ")"
+	}
+}
+
+//flip方法返回相反的边界类型。一个区间的下端点被用作另一个区间的上端点时（反之亦然），
+//边界类型需要取反，比如，从[1,5)中去掉[3,5)，剩余部分的上端点是3，其边界类型是开边界。
+//无界的端点取反之后仍然是无界的。
+func (bt BoundType) flip() BoundType {
+	switch bt {
+	case Closed:
+		return Open
+	case Open:
+		return Closed
+	default:
+		return bt
+	}
+}
+
+//boundPair记录了区间左右两个端点的边界类型，具体区间类型使用它来保存边界类型。
+//boundPair的零值表示左闭右开，因而，NumberRange与SeqRange的零值仍然是左闭右开区间。
+type boundPair struct {
+	leftOpen       bool
+	rightClosed    bool
+	leftUnbounded  bool
+	rightUnbounded bool
+}
+
+//makeBoundPair函数用给定的左右边界类型构造一个boundPair。
+func makeBoundPair(left, right BoundType) boundPair {
+	return boundPair{
+		leftOpen:       left == Open,
+		rightClosed:    right == Closed,
+		leftUnbounded:  left == Unbounded,
+		rightUnbounded: right == Unbounded,
+	}
+}
+
+//types方法返回左右两个端点的边界类型。
+func (bp boundPair) types() (left, right BoundType) {
+	left, right = Closed, Open
+	if bp.leftOpen {
+		left = Open
+	}
+	if bp.leftUnbounded {
+		left = Unbounded
+	}
+	if bp.rightClosed {
+		right = Closed
+	}
+	if bp.rightUnbounded {
+		right = Unbounded
+	}
+	return
+}
+
+//endpoint是区间端点在ranges包内部的表示，由端点的值与端点的边界类型构成。
+type endpoint[P any] struct {
+	value P
+	bound BoundType
+}
+
+//emptyBound是ranges包内部使用的边界类型，用作emptyRanger创建的空区间的两个端点的边界类型。
+//这样的端点没有值，空的下端点在所有下端点之后，空的上端点在所有上端点之前，因而它们构成的区间不包含任何点。
+const emptyBound = Unbounded + 1
+
+//emptyRanger是具体区间类型可以选择实现的接口，用于创建不需要任何端点值的空区间。
+//对于零值的点不能参与比较的区间类型，比如，点的类型是*big.Rat的CmpRange，
+//从(-∞,+∞)中去掉(-∞,+∞)时，没有有界的端点可以用来创建空区间，emptyOf函数就使用emptyRange方法。
+//isEmptyRange方法判断区间是否是由emptyRange方法创建的空区间，ranges包的函数不会比较这样的区间的端点值。
+type emptyRanger[R any] interface {
+	emptyRange() R
+	isEmptyRange() bool
+}
+
+//endpoints函数返回区间r的下端点（起点）与上端点（终点）。
+func endpoints[P any, R any](r Range[P, R]) (lower, upper endpoint[P]) {
+	if e, ok := (interface{})(r).(emptyRanger[R]); ok && e.isEmptyRange() {
+		return endpoint[P]{bound: emptyBound}, endpoint[P]{bound: emptyBound}
+	}
+	start, end := r.DeRange()
+	left, right := r.Bounds()
+	return endpoint[P]{start, left}, endpoint[P]{end, right}
+}
+
+//withEndpoints函数借助区间r，用给定的下端点与上端点创建一个新的区间。
+func withEndpoints[P any, R any](r Range[P, R], lower, upper endpoint[P]) R {
+	return r.RangeWithBounds(lower.value, upper.value, lower.bound, upper.bound)
+}
+
+//comparePoints函数借助区间r比较点a与点b的先后，a在b之前返回-1，a在b之后返回1，二者相同返回0。
+//对于左闭右开区间[b,b)，当且仅当点a在b之前时，该区间在点a之后，所以，只需要借助具体区间类型
+//实现的Range与IsAfterPoint方法，就可以比较任意两个点的先后，而不需要对点的类型P做任何额外的约束。
+//这要求具体区间类型的Range(b,b)保留端点b，因此，NumberRange把+Inf的起点与-Inf的终点化为空区间，
+//从而任何区间的有界端点都不会是无穷大，参见CreateNumberRangeWithBounds。
+func comparePoints[P any, R any](r Range[P, R], a, b P) int {
+	if typeTo[R, Range[P, R]](r.Range(b, b)).IsAfterPoint(a) {
+		return -1
+	}
+	if typeTo[R, Range[P, R]](r.Range(a, a)).IsAfterPoint(b) {
+		return 1
+	}
+	return 0
+}
+
+//compareLower函数比较两个下端点的先后。无界的下端点（-∞）在所有有界的下端点之前，
+//端点的值相同时，闭边界的下端点在开边界的下端点之前。
+func compareLower[P any, R any](r Range[P, R], a, b endpoint[P]) int {
+	if a.bound == emptyBound || b.bound == emptyBound {
+		return compareExtreme(a, b, emptyBound, 1)
+	}
+	if a.bound == Unbounded || b.bound == Unbounded {
+		return compareExtreme(a, b, Unbounded, -1)
+	}
+	if c := comparePoints(r, a.value, b.value); c != 0 || a.bound == b.bound {
+		return c
+	}
+	if a.bound == Closed {
+		return -1
+	}
+	return 1
+}
+
+//compareUpper函数比较两个上端点的先后。无界的上端点（+∞）在所有有界的上端点之后，
+//端点的值相同时，开边界的上端点在闭边界的上端点之前。
+func compareUpper[P any, R any](r Range[P, R], a, b endpoint[P]) int {
+	if a.bound == emptyBound || b.bound == emptyBound {
+		return compareExtreme(a, b, emptyBound, -1)
+	}
+	if a.bound == Unbounded || b.bound == Unbounded {
+		return compareExtreme(a, b, Unbounded, 1)
+	}
+	if c := comparePoints(r, a.value, b.value); c != 0 || a.bound == b.bound {
+		return c
+	}
+	if a.bound == Open {
+		return -1
+	}
+	return 1
+}
+
+//compareExtreme函数比较两个至少有一个的边界类型是bound的端点，position是这样的端点相对于其他端点的位置。
+//对于无界的端点，下端点是-1（-∞），上端点是1（+∞）；对于空区间的端点（emptyBound），下端点是1，上端点是-1。
+func compareExtreme[P any](a, b endpoint[P], bound BoundType, position int) int {
+	switch {
+	case a.bound == b.bound:
+		return 0
+	case a.bound == bound:
+		return position
+	default:
+		return -position
+	}
+}
+
+//isEmptySpan函数判断由下端点lower与上端点upper构成的区间是否不包含任何点，
+//也就是下端点在上端点之后，或者二者的值相同但不都是闭边界。具有无界端点的区间总是包含点的，
+//而具有空区间的端点（emptyBound）的区间总是不包含点的。
+func isEmptySpan[P any, R any](r Range[P, R], lower, upper endpoint[P]) bool {
+	if lower.bound == emptyBound || upper.bound == emptyBound {
+		return true
+	}
+	if lower.bound == Unbounded || upper.bound == Unbounded {
+		return false
+	}
+	c := comparePoints(r, lower.value, upper.value)
+	return c > 0 || c == 0 && (lower.bound == Open || upper.bound == Open)
+}
+
+//maxLower函数返回两个下端点中靠后的一个。
+func maxLower[P any, R any](r Range[P, R], a, b endpoint[P]) endpoint[P] {
+	if compareLower(r, a, b) < 0 {
+		return b
+	}
+	return a
+}
+
+//minLower函数返回两个下端点中靠前的一个。
+func minLower[P any, R any](r Range[P, R], a, b endpoint[P]) endpoint[P] {
+	if compareLower(r, a, b) > 0 {
+		return b
+	}
+	return a
+}
+
+//maxUpper函数返回两个上端点中靠后的一个。
+func maxUpper[P any, R any](r Range[P, R], a, b endpoint[P]) endpoint[P] {
+	if compareUpper(r, a, b) < 0 {
+		return b
+	}
+	return a
+}
+
+//minUpper函数返回两个上端点中靠前的一个。
+func minUpper[P any, R any](r Range[P, R], a, b endpoint[P]) endpoint[P] {
+	if compareUpper(r, a, b) > 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,129 @@
+package ranges
+
+import (
+	"testing"
+	"time"
+)
+
+//有界端点的边界类型对于连续的数轴才有意义，整数区间会被化为规范形式，参见TestIntegerNumberRange
+func TestBoundTypes(t *testing.T) {
+	nr := CreateNumberRangeWithBounds[float64]
+	closed := nr(1, 3, Closed, Closed)
+	if closed.String() != "[1,3]" || nr(5, 1, Open, Closed).String() != "[1,5)" {
+		t.Errorf("String: got %s and %s", closed.String(), nr(5, 1, Open, Closed).String())
+	}
+	for p, want := range map[float64]bool{0: false, 1: true, 3: true, 4: false} {
+		if closed.IsIncludedPoint(p) != want {
+			t.Errorf("%s.IsIncludedPoint(%v) = %v", closed.String(), p, !want)
+		}
+	}
+	if ok, r := closed.Intersect(CreateNumberRange[float64](3, 5)); !ok || r.String() != "[3,3]" {
+		t.Errorf("[1,3]*[3,5): got %s, %v", r.String(), ok)
+	}
+	if ok, _ := CreateNumberRange[float64](1, 3).Intersect(CreateNumberRange[float64](3, 5)); ok {
+		t.Errorf("[1,3)*[3,5) should not intersect")
+	}
+	unions := []struct {
+		a, b       NumberRange[float64]
+		want       string
+		successive bool
+	}{
+		{CreateNumberRange[float64](1, 3), CreateNumberRange[float64](3, 5), "[1,5)", true},
+		{CreateNumberRange[float64](1, 3), nr(3, 5, Open, Open), "[1,5)", false},
+		{closed, nr(3, 5, Open, Open), "[1,5)", true},
+		{nr(1, 3, Open, Open), nr(1, 3, Closed, Open), "[1,3)", true},
+		{nr(1, 3, Open, Open), nr(1, 3, Open, Closed), "(1,3]", true},
+	}
+	for _, c := range unions {
+		if ok, u := c.a.Union(c.b); ok != c.successive || u.String() != c.want {
+			t.Errorf("%s+%s: got %s, %v", c.a.String(), c.b.String(), u.String(), ok)
+		}
+	}
+	excepts := []struct {
+		a, b   NumberRange[float64]
+		r1, r2 string
+	}{
+		{nr(1, 5, Closed, Closed), CreateNumberRange[float64](2, 3), "[1,2)", "[3,5]"},
+		{nr(1, 5, Closed, Closed), nr(1, 3, Open, Closed), "[1,1]", "(3,5]"},
+		{CreateNumberRange[float64](1, 5), closed, "(3,5)", "empty"},
+		{closed, CreateNumberRange[float64](0, 5), "empty", "empty"},
+	}
+	for _, c := range excepts {
+		if r1, r2 := c.a.Except(c.b); r1.String() != c.r1 || r2.String() != c.r2 {
+			t.Errorf("%s-%s: got %s and %s", c.a.String(), c.b.String(), r1.String(), r2.String())
+		}
+	}
+	if !CreateNumberRange[float64](1, 3).IsBefore(nr(3, 5, Open, Open)) || closed.IsBefore(CreateNumberRange[float64](3, 5)) ||
+		!closed.IsBefore(nr(3, 5, Open, Open)) || !nr(3, 5, Open, Open).IsAfter(closed) {
+		t.Errorf("IsBefore/IsAfter ignore bound types")
+	}
+	if closed.Equal(CreateNumberRange[float64](1, 3)) || !closed.Equal(nr(3, 1, Closed, Closed)) {
+		t.Errorf("Equal ignores bound types")
+	}
+	set := NewRangeSet[float64, NumberRange[float64]](CreateNumberRange[float64](1, 3), nr(3, 5, Open, Closed), closed)
+	if set.String() != "{[1,5]}" || set.Contains(0) || !set.Contains(5) {
+		t.Errorf("RangeSet: got %s", set.String())
+	}
+	gap := NewRangeSet[float64, NumberRange[float64]](CreateNumberRange[float64](1, 3), nr(3, 5, Open, Open))
+	if gap.Len() != 2 || gap.Contains(3) || !gap.ContainsRange(nr(3, 4, Open, Closed)) {
+		t.Errorf("RangeSet: got %s", gap.String())
+	}
+
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	ti := CreateTimeIntervalWithBounds(day, day.Add(24*time.Hour), Closed, Closed)
+	if !ti.IsIncludedPoint(day.Add(24*time.Hour)) || Tintvl2Str(ti) != "[2022-01-01 00:00:00,2022-01-02 00:00:00]" {
+		t.Errorf("TimeInterval: got %s", Tintvl2Str(ti))
+	}
+}
+
+func TestUnboundedRanges(t *testing.T) {
+	atLeast := CreateNumberRangeFrom(10, Closed)
+	below := CreateNumberRangeTo(100, Open)
+	all := CreateUnboundedNumberRange[int]()
+	if atLeast.String() != "[10,+∞)" || below.String() != "(-∞,100)" || all.String() != "(-∞,+∞)" {
+		t.Errorf("String: got %s, %s and %s", atLeast.String(), below.String(), all.String())
+	}
+	if !atLeast.IsIncludedPoint(1<<40) || atLeast.IsIncludedPoint(9) || !below.IsIncludedPoint(-1<<40) ||
+		!all.IsIncludedPoint(0) || all.IsPoint() {
+		t.Errorf("IsIncludedPoint ignores unbounded ends")
+	}
+	if ok, r := atLeast.Intersect(below); !ok || r.String() != "[10,100)" {
+		t.Errorf("[10,+∞)*(-∞,100): got %s, %v", r.String(), ok)
+	}
+	if ok, r := atLeast.Union(below); !ok || !r.Equal(all) {
+		t.Errorf("[10,+∞)+(-∞,100): got %s, %v", r.String(), ok)
+	}
+	if ok, r := CreateNumberRange(0, 5).Union(atLeast); ok || r.String() != "[0,+∞)" {
+		t.Errorf("[0,5)+[10,+∞): got %s, %v", r.String(), ok)
+	}
+	if r1, r2 := all.Except(CreateNumberRange(0, 5)); r1.String() != "(-∞,0)" || r2.String() != "[5,+∞)" {
+		t.Errorf("(-∞,+∞)-[0,5): got %s and %s", r1.String(), r2.String())
+	}
+	if r1, r2 := atLeast.Except(below); r1.String() != "[100,+∞)" || r2.String() != "empty" {
+		t.Errorf("[10,+∞)-(-∞,100): got %s and %s", r1.String(), r2.String())
+	}
+	if !below.IsBefore(CreateNumberRangeFrom(100, Closed)) || below.IsBefore(atLeast) || atLeast.IsBefore(all) ||
+		!CreateNumberRangeFrom(100, Open).IsAfter(CreateNumberRange(0, 100)) {
+		t.Errorf("IsBefore/IsAfter ignore unbounded ends")
+	}
+	set := NewRangeSet[int, NumberRange[int]](CreateNumberRange(0, 5), atLeast, below)
+	if set.String() != "{(-∞,+∞)}" {
+		t.Errorf("RangeSet: got %s", set.String())
+	}
+	if got := NewRangeSet[int, NumberRange[int]](CreateNumberRange(0, 5)).Complement(all); got.String() != "{(-∞,0),[5,+∞)}" {
+		t.Errorf("Complement: got %s", got.String())
+	}
+
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	contract := CreateTimeIntervalFrom(from, Closed)
+	if FmtTintvl(contract, "2006-01-02") != "[2022-01-01,+∞)" {
+		t.Errorf("TimeInterval: got %s", FmtTintvl(contract, "2006-01-02"))
+	}
+	if !contract.IsIncludedPoint(from.AddDate(100, 0, 0)) || contract.IsIncludedPoint(from.Add(-time.Second)) {
+		t.Errorf("TimeInterval ignores unbounded end")
+	}
+	f := &TICycleFunc{}
+	if next := f.OfCycles(contract, 1, TimeCycle{Count: 1, Unit: time.Hour}); FmtTintvl(next, "15:04") != "[01:00,+∞)" {
+		t.Errorf("OfCycles: got %s", FmtTintvl(next, "15:04"))
+	}
+}
@@ -0,0 +1,365 @@
+package ranges
+
+import (
+	"math"
+	"sort"
+)
+
+//CircularRange[P number]定义了模为modulus的环上的区间，比如，360度的罗盘上的风向区间[350,10)，
+//或者一致性哈希环上的令牌区间。环上的点的取值范围是[0,modulus)，区间总是左闭右开的，从起点开始沿着环增大的方向到终点为止，
+//起点在终点之后的区间跨越环的零点，比如，[350,10)包含350至360之间与0至10之间的点。
+//区间的运算与NumberRange相同，但是结果会考虑环绕：两个区间的交集与差集可能分为两段，所以，Intersect与Except方法返回区间切片。
+//运算结果的端点总是取自参与运算的区间的端点，而不是经过加减与取模重新计算得到，因而，浮点数的端点不会产生误差，
+//运算过程中的数值也不会超过modulus，所以，modulus可以是类型P能够表示的任何正数，比如，uint64的哈希环。
+//modulus本身必须能够用类型P表示，比如，2^32的哈希环不能使用uint32，而应该把令牌转换为uint64，再使用模为1<<32的uint64环。
+//空区间的起点与终点被规范为0，全环被规范为[0,modulus)，因而，可以使用==比较两个区间。
+type CircularRange[P number] struct {
+	start   P //起点，取值范围是[0,modulus)
+	end     P //终点，取值范围是[0,modulus)，只有全环的终点是modulus
+	modulus P //环的模
+}
+
+//CreateCircularRange函数用起点start与终点end创建模为modulus的环上的左闭右开区间，起点在终点之后时，区间跨越环的零点，
+//起点等于终点时，区间是空的，比如，[360,360)与[10,10)都是空的，只有字面上的[0,modulus)是全环。
+//超出[0,modulus]的点按modulus取模。modulus不是正数时会panic。
+func CreateCircularRange[P number](modulus, start, end P) CircularRange[P] {
+	checkPositiveModulus(modulus)
+	if start == 0 && end == modulus {
+		return CreateFullCircularRange(modulus)
+	}
+	return circularOf(modulus, modulo(start, modulus), modulo(end, modulus))
+}
+
+//CreateFullCircularRange函数创建模为modulus的全环区间[0,modulus)。modulus不是正数时会panic。
+func CreateFullCircularRange[P number](modulus P) CircularRange[P] {
+	checkPositiveModulus(modulus)
+	return CircularRange[P]{end: modulus, modulus: modulus}
+}
+
+//Modulus方法返回环的模。
+func (cr CircularRange[P]) Modulus() P {
+	return cr.modulus
+}
+
+//Start方法返回区间的起点。
+func (cr CircularRange[P]) Start() P {
+	return cr.start
+}
+
+//End方法返回区间的终点，跨越零点的区间，终点在起点之前。终点是零点时返回modulus，而不是0。
+func (cr CircularRange[P]) End() P {
+	if cr.end == 0 && !cr.IsEmpty() {
+		return cr.modulus
+	}
+	return cr.end
+}
+
+//Length方法返回区间的长度，比如，360度的环上，[350,10)的长度是20。
+func (cr CircularRange[P]) Length() P {
+	if cr.start <= cr.end {
+		return cr.end - cr.start
+	}
+	return cr.modulus - cr.start + cr.end
+}
+
+//IsEmpty方法判断区间是否是空区间。
+func (cr CircularRange[P]) IsEmpty() bool {
+	return cr.start == cr.end
+}
+
+//IsFull方法判断区间是否是全环。
+func (cr CircularRange[P]) IsFull() bool {
+	return cr.end == cr.modulus && cr.modulus != 0
+}
+
+//IsWrapped方法判断区间是否跨越环的零点，也就是起点在终点之后，比如，[350,10)。终点是零点的区间不跨越零点。
+func (cr CircularRange[P]) IsWrapped() bool {
+	return cr.end != 0 && cr.end < cr.start
+}
+
+//IsIncludedPoint方法判断点p是否在区间之中，p按modulus取模之后判断。
+func (cr CircularRange[P]) IsIncludedPoint(p P) bool {
+	if cr.IsEmpty() {
+		return false
+	}
+	p = modulo(p, cr.modulus)
+	if cr.start < cr.end {
+		return cr.start <= p && p < cr.end
+	}
+	return p >= cr.start || p < cr.end
+}
+
+//Equal方法判断两个区间是否相同，所有模相同的空区间都是相同的。
+func (cr CircularRange[P]) Equal(other CircularRange[P]) bool {
+	return cr == other
+}
+
+//IsIntersected方法判断两个区间是否相交。
+func (cr CircularRange[P]) IsIntersected(other CircularRange[P]) bool {
+	return len(cr.Intersect(other)) > 0
+}
+
+//Intersect方法求两个区间的交集。两个区间可能在两处相交，比如，[300,60)与[30,330)的交集是[30,60)与[300,330)，
+//所以，交集是按起点排序的零个、一个或者两个区间。两个区间的模不同时，Intersect会panic，Union与Except也是如此。
+func (cr CircularRange[P]) Intersect(other CircularRange[P]) []CircularRange[P] {
+	cr.checkModulus(other)
+	if cr.IsEmpty() || other.IsEmpty() {
+		return nil
+	}
+	if cr.IsFull() || other.IsFull() {
+		if cr.IsFull() {
+			return []CircularRange[P]{other}
+		}
+		return []CircularRange[P]{cr}
+	}
+	this := cr.unroll(cr.start)
+	var result []CircularRange[P]
+	for _, that := range other.unrolledCopies(cr.start) {
+		if r := this.intersect(that); !r.isEmpty() {
+			result = append(result, r.circular(cr.modulus))
+		}
+	}
+	return sortCircularRanges(result)
+}
+
+//Union方法求两个区间的并集，返回的布尔值表示两个区间是否相交或者相邻，只有这时并集才是一个区间。
+//比如，[350,10)与[0,30)的并集是[350,30)，[0,200)与[180,20)的并集是全环。
+//两个区间不相连时，返回false，以及从cr的起点开始、沿着环到other的终点为止的区间。空区间与任何区间的并集都是该区间本身。
+func (cr CircularRange[P]) Union(other CircularRange[P]) (bool, CircularRange[P]) {
+	cr.checkModulus(other)
+	if cr.IsEmpty() {
+		return true, other
+	}
+	if other.IsEmpty() {
+		return true, cr
+	}
+	if cr.IsFull() || other.IsFull() {
+		return true, CreateFullCircularRange(cr.modulus)
+	}
+	this := cr.unroll(cr.start)
+	connected := 0
+	var span ringSpan[P]
+	for _, that := range other.unrolledCopies(cr.start) {
+		if !that.start.after(this.end) && !this.start.after(that.end) {
+			connected++
+			span = this.union(that)
+		}
+	}
+	if connected == 0 {
+		return false, circularOf(cr.modulus, cr.start, other.end)
+	}
+	//并集的终点回到或者越过了起点，说明并集覆盖了全环
+	if connected > 1 || !span.end.before(ringPos[P]{span.start.lap + 1, span.start.value}) {
+		return true, CreateFullCircularRange(cr.modulus)
+	}
+	return true, span.circular(cr.modulus)
+}
+
+//Except方法求区间cr减去other的差集，差集可能分为两段，比如，[300,60)减去[0,10)的结果是[10,60)与[300,360)，
+//所以，差集是按起点排序的零个、一个或者两个区间。
+func (cr CircularRange[P]) Except(other CircularRange[P]) []CircularRange[P] {
+	cr.checkModulus(other)
+	if cr.IsEmpty() || other.IsFull() {
+		return nil
+	}
+	if other.IsEmpty() {
+		return []CircularRange[P]{cr}
+	}
+	if cr.IsFull() {
+		return []CircularRange[P]{other.Complement()}
+	}
+	pieces := []ringSpan[P]{cr.unroll(cr.start)}
+	for _, that := range other.unrolledCopies(cr.start) {
+		var rest []ringSpan[P]
+		for _, piece := range pieces {
+			rest = append(rest, piece.except(that)...)
+		}
+		pieces = rest
+	}
+	result := make([]CircularRange[P], 0, len(pieces))
+	for _, piece := range pieces {
+		result = append(result, piece.circular(cr.modulus))
+	}
+	return sortCircularRanges(result)
+}
+
+//Complement方法求区间在环上的补集，比如，[350,10)的补集是[10,350)。
+func (cr CircularRange[P]) Complement() CircularRange[P] {
+	switch {
+	case cr.IsEmpty():
+		return CreateFullCircularRange(cr.modulus)
+	case cr.IsFull():
+		return CircularRange[P]{modulus: cr.modulus}
+	}
+	return circularOf(cr.modulus, cr.end, cr.start)
+}
+
+//Ranges方法将区间拆分为[0,modulus)之中的数字区间，不跨越零点的区间拆分为一个数字区间，
+//跨越零点的区间拆分为两个数字区间，比如，[350,10)拆分为[0,10)与[350,360)，结果按起点排序，可以直接用于RangeSet。
+func (cr CircularRange[P]) Ranges() []NumberRange[P] {
+	if cr.IsEmpty() {
+		return nil
+	}
+	if !cr.IsWrapped() {
+		return []NumberRange[P]{CreateNumberRange(cr.start, cr.End())}
+	}
+	return []NumberRange[P]{CreateNumberRange(0, cr.end), CreateNumberRange(cr.start, cr.modulus)}
+}
+
+//String方法输出区间的文本形式，比如，[350,10)，空区间输出“empty”。
+func (cr CircularRange[P]) String() string {
+	if cr.IsEmpty() {
+		return "empty"
+	}
+	return "[" + v2s(cr.start) + "," + v2s(cr.End()) + ")"
+}
+
+//unroll方法将既不为空也不是全环的区间展开为从base开始的数轴上的一段，在base之前的起点被移到下一圈。
+func (cr CircularRange[P]) unroll(base P) ringSpan[P] {
+	start := ringPos[P]{0, cr.start}
+	if cr.start < base {
+		start.lap = 1
+	}
+	end := ringPos[P]{start.lap, cr.end}
+	if cr.end <= cr.start {
+		end.lap++
+	}
+	return ringSpan[P]{start, end}
+}
+
+//unrolledCopies方法返回展开之后的区间，以及它在上一圈的副本，从base开始的一圈之中的区间只可能与这两段相交或者相邻。
+func (cr CircularRange[P]) unrolledCopies(base P) [2]ringSpan[P] {
+	r := cr.unroll(base)
+	return [2]ringSpan[P]{r, {ringPos[P]{r.start.lap - 1, r.start.value}, ringPos[P]{r.end.lap - 1, r.end.value}}}
+}
+
+func (cr CircularRange[P]) checkModulus(other CircularRange[P]) {
+	if cr.modulus != other.modulus {
+		panic("ranges: circular ranges with different moduli")
+	}
+}
+
+//checkPositiveModulus函数检查环的模是否是正数，否则panic，以免取模时除以0。
+func checkPositiveModulus[P number](modulus P) {
+	if !(modulus > 0) {
+		panic("ranges: the modulus of a circular range must be positive")
+	}
+}
+
+//ringPos是环展开之后的数轴上的位置，也就是第lap圈的点value，位置的先后依次比较圈数与点的值，
+//计算过程中不需要对点的值做任何加减，因而不会产生误差或者溢出。
+type ringPos[P number] struct {
+	lap   int
+	value P
+}
+
+func (p ringPos[P]) before(q ringPos[P]) bool {
+	return p.lap < q.lap || p.lap == q.lap && p.value < q.value
+}
+
+func (p ringPos[P]) after(q ringPos[P]) bool {
+	return q.before(p)
+}
+
+//ringSpan是环展开之后的数轴上的左闭右开区间，长度不超过一圈。
+type ringSpan[P number] struct {
+	start, end ringPos[P]
+}
+
+func (s ringSpan[P]) isEmpty() bool {
+	return !s.start.before(s.end)
+}
+
+func (s ringSpan[P]) intersect(other ringSpan[P]) ringSpan[P] {
+	if s.start.before(other.start) {
+		s.start = other.start
+	}
+	if other.end.before(s.end) {
+		s.end = other.end
+	}
+	return s
+}
+
+func (s ringSpan[P]) union(other ringSpan[P]) ringSpan[P] {
+	if other.start.before(s.start) {
+		s.start = other.start
+	}
+	if s.end.before(other.end) {
+		s.end = other.end
+	}
+	return s
+}
+
+//except方法求s减去other剩余的零段、一段或者两段。
+func (s ringSpan[P]) except(other ringSpan[P]) []ringSpan[P] {
+	if s.intersect(other).isEmpty() {
+		return []ringSpan[P]{s}
+	}
+	var result []ringSpan[P]
+	if s.start.before(other.start) {
+		result = append(result, ringSpan[P]{s.start, other.start})
+	}
+	if other.end.before(s.end) {
+		result = append(result, ringSpan[P]{other.end, s.end})
+	}
+	return result
+}
+
+//circular方法将长度小于一圈的非空区间卷回到模为modulus的环上。
+func (s ringSpan[P]) circular(modulus P) CircularRange[P] {
+	return circularOf(modulus, s.start.value, s.end.value)
+}
+
+//circularOf函数用[0,modulus)之中的起点与终点创建区间，起点等于终点时是空区间。
+func circularOf[P number](modulus, start, end P) CircularRange[P] {
+	if start == end {
+		return CircularRange[P]{modulus: modulus}
+	}
+	return CircularRange[P]{start: start, end: end, modulus: modulus}
+}
+
+//circularOfLength函数用[0,modulus)之中的起点与长度创建区间，长度不小于modulus时是全环。
+func circularOfLength[P number](modulus, start, length P) CircularRange[P] {
+	switch {
+	case length <= 0:
+		return CircularRange[P]{modulus: modulus}
+	case length >= modulus:
+		return CreateFullCircularRange(modulus)
+	case length < modulus-start:
+		return circularOf(modulus, start, start+length)
+	}
+	return circularOf(modulus, start, length-(modulus-start))
+}
+
+//sortCircularRanges函数将区间按起点排序。
+func sortCircularRanges[P number](rs []CircularRange[P]) []CircularRange[P] {
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].start < rs[j].start
+	})
+	return rs
+}
+
+//modulo函数求p除以正数m的非负余数，结果的取值范围是[0,m)。
+func modulo[P number](p, m P) P {
+	var one P = 1
+	switch {
+	case one/2 != 0: //浮点数
+		p = P(math.Mod(float64(p), float64(m)))
+		if p < 0 {
+			p += m
+		}
+		//p是绝对值极小的负数时，p+m可能被舍入为m
+		if p >= m {
+			p = 0
+		}
+		return p
+	case -one < 0: //有符号整数
+		r := int64(p) % int64(m)
+		if r < 0 {
+			r += int64(m)
+		}
+		return P(r)
+	default:
+		return P(uint64(p) % uint64(m))
+	}
+}
@@ -0,0 +1,157 @@
+package ranges
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func joinCircularRanges[P number](rs []CircularRange[P]) string {
+	s := ""
+	for i, r := range rs {
+		if i > 0 {
+			s += " "
+		}
+		s += r.String()
+	}
+	return s
+}
+
+func TestCircularRange(t *testing.T) {
+	north := CreateCircularRange(360.0, 350, 10)
+	if north.String() != "[350,10)" || !north.IsWrapped() || north.Length() != 20 {
+		t.Errorf("CreateCircularRange: got %s", north)
+	}
+	if r := CreateCircularRange(360.0, -10, 370); !r.Equal(north) {
+		t.Errorf("points should be taken modulo 360, got %s", r)
+	}
+	if !CreateCircularRange(360, 90, 90).IsEmpty() || !CreateCircularRange(360, 0, 360).IsFull() || CreateCircularRange(360, 270, 360).IsWrapped() {
+		t.Errorf("empty, full and zero-ending ranges: wrong result")
+	}
+	if CreateCircularRange(360, 10, 10) != CreateCircularRange(360, 20, 20) {
+		t.Errorf("empty ranges should be equal")
+	}
+	//只有字面上的[0,modulus)是全环，起点与终点相同的区间总是空的
+	for _, r := range []CircularRange[int]{CreateCircularRange(360, 360, 360), CreateCircularRange(360, 720, 360), CreateCircularRange(360, 0, 720)} {
+		if !r.IsEmpty() {
+			t.Errorf("%s should be empty", r)
+		}
+	}
+	for _, modulus := range []float64{0, -360, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("CreateCircularRange with modulus %v should panic", modulus)
+				}
+			}()
+			CreateCircularRange(modulus, 10, 20)
+		}()
+	}
+	for p, want := range map[float64]bool{349.9: false, 350: true, 359.5: true, 0: true, 360: true, -5: true, 9.99: true, 10: false, 180: false} {
+		if got := north.IsIncludedPoint(p); got != want {
+			t.Errorf("IsIncludedPoint(%v) = %v", p, got)
+		}
+	}
+	if s := fmt.Sprint(north.Ranges()); s != "[[0,10) [350,360)]" {
+		t.Errorf("Ranges: got %s", s)
+	}
+	if c := north.Complement(); c.String() != "[10,350)" || !CreateFullCircularRange(360).Complement().IsEmpty() {
+		t.Errorf("Complement: got %s", c)
+	}
+}
+
+func TestCircularRangeAlgebra(t *testing.T) {
+	cr := func(start, end int) CircularRange[int] { return CreateCircularRange(360, start, end) }
+	cases := []struct {
+		a, b      CircularRange[int]
+		intersect string
+		except    string
+		connected bool
+		union     string
+	}{
+		{cr(300, 60), cr(30, 330), "[30,60) [300,330)", "[330,30)", true, "[0,360)"},
+		{cr(350, 10), cr(0, 30), "[0,10)", "[350,360)", true, "[350,30)"},
+		{cr(0, 30), cr(350, 10), "[0,10)", "[10,30)", true, "[350,30)"},
+		{cr(300, 60), cr(0, 10), "[0,10)", "[10,60) [300,360)", true, "[300,60)"},
+		{cr(350, 10), cr(10, 20), "", "[350,10)", true, "[350,20)"},
+		{cr(350, 10), cr(90, 180), "", "[350,10)", false, "[350,180)"},
+		{cr(350, 10), cr(0, 360), "[350,10)", "", true, "[0,360)"},
+		{cr(0, 360), cr(350, 10), "[350,10)", "[10,350)", true, "[0,360)"},
+		{cr(10, 10), cr(350, 10), "", "", true, "[350,10)"},
+	}
+	for _, c := range cases {
+		if got := joinCircularRanges(c.a.Intersect(c.b)); got != c.intersect {
+			t.Errorf("%s ∩ %s = %q, want %q", c.a, c.b, got, c.intersect)
+		}
+		if got := c.a.IsIntersected(c.b); got != (c.intersect != "") {
+			t.Errorf("%s IsIntersected %s = %v", c.a, c.b, got)
+		}
+		if got := joinCircularRanges(c.a.Except(c.b)); got != c.except {
+			t.Errorf("%s - %s = %q, want %q", c.a, c.b, got, c.except)
+		}
+		if ok, got := c.a.Union(c.b); ok != c.connected || got.String() != c.union {
+			t.Errorf("%s ∪ %s = %s, %v", c.a, c.b, got, ok)
+		}
+	}
+}
+
+func TestCircularRangeFloat(t *testing.T) {
+	//运算结果的端点取自原来的区间，不会因为平移与取模产生误差
+	small := CreateCircularRange(360.0, 0.1, 0.3)
+	if rest := small.Except(CreateCircularRange(360.0, 100, 200)); len(rest) != 1 || rest[0] != small {
+		t.Errorf("Except of a disjoint range: got %s", joinCircularRanges(rest))
+	}
+	if r := small.Intersect(CreateCircularRange(360.0, 350, 10)); len(r) != 1 || r[0] != small {
+		t.Errorf("Intersect: got %s", joinCircularRanges(r))
+	}
+	wind := CreateCircularRange(360.0, 337.5, 22.5)
+	cases := []struct {
+		other           CircularRange[float64]
+		intersect, rest string
+	}{
+		{CreateCircularRange(360.0, 0.1, 0.7), "[0.1,0.7)", "[0.7,22.5) [337.5,0.1)"},
+		{CreateCircularRange(360.0, 355.3, 0.3), "[355.3,0.3)", "[0.3,22.5) [337.5,355.3)"},
+		{CreateCircularRange(360.0, 22.4, 337.6), "[22.4,22.5) [337.5,337.6)", "[337.6,22.4)"},
+	}
+	for _, c := range cases {
+		if got := joinCircularRanges(wind.Intersect(c.other)); got != c.intersect {
+			t.Errorf("%s ∩ %s = %s, want %s", wind, c.other, got, c.intersect)
+		}
+		if got := joinCircularRanges(wind.Except(c.other)); got != c.rest {
+			t.Errorf("%s - %s = %s, want %s", wind, c.other, got, c.rest)
+		}
+	}
+	if ok, u := small.Union(CreateCircularRange(360.0, 0.3, 0.7)); !ok || u != CreateCircularRange(360.0, 0.1, 0.7) {
+		t.Errorf("Union: got %s, %v", u, ok)
+	}
+}
+
+func TestCircularRangeHashRing(t *testing.T) {
+	//2^32的哈希环不能用uint32表示模，令牌转换为uint64
+	const ring = uint64(1) << 32
+	owned := CreateCircularRange(ring, ring-100, 100)
+	if !owned.IsIncludedPoint(ring-1) || !owned.IsIncludedPoint(0) || owned.IsIncludedPoint(100) || owned.Length() != 200 {
+		t.Errorf("IsIncludedPoint on the hash ring: wrong result")
+	}
+	moved := CreateCircularRange(ring, ring-50, 50)
+	if rest := owned.Except(moved); joinCircularRanges(rest) != "[50,100) [4294967196,4294967246)" {
+		t.Errorf("Except on the hash ring: got %s", joinCircularRanges(rest))
+	}
+	set := NewRangeSet[uint64, NumberRange[uint64]](owned.Ranges()...)
+	if set.String() != "{[0,100),[4294967196,4294967296)}" {
+		t.Errorf("Ranges in a RangeSet: got %s", set.String())
+	}
+	//模接近uint64的最大值时，运算也不会溢出
+	const wide = uint64(math.MaxUint64)
+	a := CreateCircularRange(wide, wide-10, 10)
+	b := CreateCircularRange(wide, 5, wide-5)
+	if got := joinCircularRanges(a.Intersect(b)); got != "[5,10) [18446744073709551605,18446744073709551610)" {
+		t.Errorf("Intersect on a wide ring: got %s", got)
+	}
+	if got := joinCircularRanges(a.Except(b)); got != "[18446744073709551610,5)" {
+		t.Errorf("Except on a wide ring: got %s", got)
+	}
+	if ok, u := a.Union(b); !ok || !u.IsFull() || a.Length() != 20 || !a.IsIncludedPoint(wide-3) || a.IsIncludedPoint(wide/2) {
+		t.Errorf("Union on a wide ring: got %s, %v", u, ok)
+	}
+}
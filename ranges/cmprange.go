@@ -0,0 +1,160 @@
+package ranges
+
+//CreateCmpRange函数用比较函数cmp与给定的两个点创建一个左闭右开区间，
+//无论两个点的先后顺序如何，创建出来的区间的起点都会在终点之前。
+//cmp(a,b)在a先于b时返回负数，a后于b时返回正数，二者相同时返回0，比如，(*big.Rat).Cmp与bytes.Compare。
+func CreateCmpRange[P any](cmp func(a, b P) int, p1, p2 P) CmpRange[P] {
+	if cmp(p1, p2) <= 0 {
+		return CmpRange[P]{start: p1, end: p2, cmp: cmp}
+	} else {
+		return CmpRange[P]{start: p2, end: p1, cmp: cmp}
+	}
+}
+
+//CreateCmpRangeWithBounds函数用比较函数cmp、给定的两个点及其边界类型创建一个区间，
+//left是p1的边界类型，right是p2的边界类型。如果p1在p2之后，两个点连同其边界类型一起交换，
+//因而，创建出来的区间的起点都会在终点之前。
+//如果有端点是无界的（Unbounded），则两个点不会交换，无界端点的值被置为零值。
+func CreateCmpRangeWithBounds[P any](cmp func(a, b P) int, p1, p2 P, left, right BoundType) CmpRange[P] {
+	if left == Unbounded || right == Unbounded {
+		var zero P
+		if left == Unbounded {
+			p1 = zero
+		}
+		if right == Unbounded {
+			p2 = zero
+		}
+		return CmpRange[P]{start: p1, end: p2, bounds: makeBoundPair(left, right), cmp: cmp}
+	}
+	if cmp(p1, p2) <= 0 {
+		return CmpRange[P]{start: p1, end: p2, bounds: makeBoundPair(left, right), cmp: cmp}
+	} else {
+		return CmpRange[P]{start: p2, end: p1, bounds: makeBoundPair(right, left), cmp: cmp}
+	}
+}
+
+//CreateCmpRangeFrom函数用比较函数cmp创建一个以start为起点、终点无界的区间，比如，[start,+∞)，
+//left是起点的边界类型。
+func CreateCmpRangeFrom[P any](cmp func(a, b P) int, start P, left BoundType) CmpRange[P] {
+	return CreateCmpRangeWithBounds(cmp, start, start, left, Unbounded)
+}
+
+//CreateCmpRangeTo函数用比较函数cmp创建一个起点无界、以end为终点的区间，比如，(-∞,end)，
+//right是终点的边界类型。
+func CreateCmpRangeTo[P any](cmp func(a, b P) int, end P, right BoundType) CmpRange[P] {
+	return CreateCmpRangeWithBounds(cmp, end, end, Unbounded, right)
+}
+
+//CreateUnboundedCmpRange函数用比较函数cmp创建一个起点与终点都无界的区间(-∞,+∞)，该区间包含所有的点。
+func CreateUnboundedCmpRange[P any](cmp func(a, b P) int) CmpRange[P] {
+	var zero P
+	return CreateCmpRangeWithBounds(cmp, zero, zero, Unbounded, Unbounded)
+}
+
+//CmpRange[P any]定义了由比较函数决定点与点之间先后顺序的区间类型，该类型的区间满足Range[P, CmpRange[P]]接口，
+//因而，Intersect、Union、Except、Coalesce等ranges包的函数，以及RangeSet、RangeMap等类型都可以用于CmpRange。
+//与NumberRange和SeqRange不同，点的类型P不必是comparable类型，也不必具有Before、After方法，
+//比如，*big.Rat、*big.Int、[]byte，或者由多个字段构成的复合键，只要给出比较函数即可。
+//由Range、RangeWithBounds等方法创建的区间沿用原有区间的比较函数。
+//CmpRange必须使用CreateCmpRange等函数创建，零值的CmpRange没有比较函数，调用其方法会导致panic。
+//无界端点的值是P的零值，比如，*big.Rat的nil，ranges包的函数不会用它调用比较函数，
+//从(-∞,+∞)中去掉(-∞,+∞)这样没有有界端点可用的运算，结果是不含端点值的空区间，其DeRange方法返回两个零值。
+type CmpRange[P any] struct {
+	start  P
+	end    P
+	bounds boundPair        //起点与终点的边界类型
+	cmp    func(a, b P) int //比较函数
+	empty  bool             //是否是不含端点值的空区间
+}
+
+func (cr CmpRange[P]) Range(start, end P) CmpRange[P] {
+	return CreateCmpRange(cr.cmp, start, end)
+}
+
+func (cr CmpRange[P]) RangeWithBounds(start, end P, left, right BoundType) CmpRange[P] {
+	return CreateCmpRangeWithBounds(cr.cmp, start, end, left, right)
+}
+
+func (cr CmpRange[P]) DeRange() (start, end P) {
+	return cr.start, cr.end
+}
+
+func (cr CmpRange[P]) Bounds() (left, right BoundType) {
+	return cr.bounds.types()
+}
+
+func (cr CmpRange[P]) IsIncludedPoint(p P) bool {
+	return !cr.empty && !cr.IsAfterPoint(p) && !cr.IsBeforePoint(p)
+}
+func (cr CmpRange[P]) IsBeforePoint(p P) bool {
+	if cr.empty {
+		return true
+	}
+	_, right := cr.Bounds()
+	if right == Unbounded {
+		return false
+	}
+	c := cr.cmp(p, cr.end)
+	return c > 0 || c == 0 && right == Open
+}
+func (cr CmpRange[P]) IsAfterPoint(p P) bool {
+	if cr.empty {
+		return true
+	}
+	left, _ := cr.Bounds()
+	if left == Unbounded {
+		return false
+	}
+	c := cr.cmp(p, cr.start)
+	return c < 0 || c == 0 && left == Open
+}
+
+//emptyRange方法创建不含端点值的空区间，参见emptyRanger。
+func (cr CmpRange[P]) emptyRange() CmpRange[P] {
+	return CmpRange[P]{cmp: cr.cmp, empty: true}
+}
+
+func (cr CmpRange[P]) isEmptyRange() bool {
+	return cr.empty
+}
+
+////////////////////////////////////////////////////////////////////
+func (cr CmpRange[P]) IsEmpty() bool {
+	return IsEmpty[P, CmpRange[P]](cr)
+}
+func (cr CmpRange[P]) IsPoint() bool {
+	return IsPoint[P, CmpRange[P]](cr)
+}
+func (cr CmpRange[P]) String() string {
+	return RngToStr[P, CmpRange[P]](cr, v2s[P])
+}
+func (cr CmpRange[P]) Equal(other CmpRange[P]) bool {
+	return Equal[P, CmpRange[P]](cr, other)
+}
+func (cr CmpRange[P]) IsIntersected(other CmpRange[P]) bool {
+	return IsIntersected[P, CmpRange[P]](cr, other)
+}
+func (cr CmpRange[P]) Intersect(other CmpRange[P]) (bool, CmpRange[P]) {
+	return Intersect[P, CmpRange[P]](cr, other)
+}
+func (cr CmpRange[P]) IntersectOthers(others []CmpRange[P]) (bool, CmpRange[P]) {
+	return IntersectOthers[P, CmpRange[P]](cr, others)
+}
+func (cr CmpRange[P]) Union(other CmpRange[P]) (bool, CmpRange[P]) {
+	return Union[P, CmpRange[P]](cr, other)
+}
+func (cr CmpRange[P]) UnionOthers(others []CmpRange[P]) (bool, CmpRange[P]) {
+	return UnionOthers[P, CmpRange[P]](cr, others)
+}
+func (cr CmpRange[P]) Except(other CmpRange[P]) (r1, r2 CmpRange[P]) {
+	return Except[P, CmpRange[P]](cr, other)
+}
+func (cr CmpRange[P]) ExceptOthers(others []CmpRange[P]) []CmpRange[P] {
+	return ExceptOthers[P, CmpRange[P]](cr, others)
+}
+func (cr CmpRange[P]) IsBefore(other CmpRange[P]) bool {
+	return IsBefore[P, CmpRange[P]](cr, other)
+}
+func (cr CmpRange[P]) IsAfter(other CmpRange[P]) bool {
+	return IsAfter[P, CmpRange[P]](cr, other)
+}
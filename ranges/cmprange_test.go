@@ -0,0 +1,110 @@
+package ranges
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestCmpRange(t *testing.T) {
+	cmp := (*big.Rat).Cmp
+	rat := func(s string) *big.Rat {
+		r, _ := new(big.Rat).SetString(s)
+		return r
+	}
+	r := CreateCmpRange(cmp, rat("2/3"), rat("1/3"))
+	if r.String() != "[1/3,2/3)" || !r.IsIncludedPoint(rat("1/2")) || r.IsIncludedPoint(rat("2/3")) || !r.IsIncludedPoint(rat("2/6")) {
+		t.Errorf("%s: wrong result", r.String())
+	}
+	if ok, u := r.Union(CreateCmpRange(cmp, rat("4/6"), rat("1"))); !ok || u.String() != "[1/3,1/1)" {
+		t.Errorf("Union: got %s, %v", u.String(), ok)
+	}
+	if ok, i := r.Intersect(CreateCmpRangeWithBounds(cmp, rat("1/2"), rat("5"), Open, Closed)); !ok || i.String() != "(1/2,2/3)" {
+		t.Errorf("Intersect: got %s, %v", i.String(), ok)
+	}
+	r1, r2 := CreateCmpRange(cmp, rat("0"), rat("1")).Except(CreateCmpRange(cmp, rat("1/4"), rat("1/2")))
+	if r1.String() != "[0/1,1/4)" || r2.String() != "[1/2,1/1)" {
+		t.Errorf("Except: got %s and %s", r1.String(), r2.String())
+	}
+	if !CreateCmpRange(cmp, rat("1/2"), rat("1/2")).IsEmpty() || !CreateCmpRangeFrom(cmp, rat("1/2"), Open).IsIncludedPoint(rat("100")) {
+		t.Errorf("empty and unbounded ranges: wrong result")
+	}
+	coalesced := Coalesce[*big.Rat, CmpRange[*big.Rat]]([]CmpRange[*big.Rat]{
+		CreateCmpRange(cmp, rat("1/2"), rat("1")), CreateCmpRange(cmp, rat("0"), rat("1/2")), CreateCmpRange(cmp, rat("2"), rat("3")),
+	})
+	if len(coalesced) != 2 || coalesced[0].String() != "[0/1,1/1)" || coalesced[1].String() != "[2/1,3/1)" {
+		t.Errorf("Coalesce: got %v", coalesced)
+	}
+}
+
+func TestCmpRangeCompositeKey(t *testing.T) {
+	//由租户与字节切片构成的复合键，结构体含有切片字段，不是comparable类型
+	type key struct {
+		tenant string
+		id     []byte
+	}
+	cmp := func(a, b key) int {
+		switch {
+		case a.tenant < b.tenant:
+			return -1
+		case a.tenant > b.tenant:
+			return 1
+		}
+		return bytes.Compare(a.id, b.id)
+	}
+	set := NewRangeSet[key, CmpRange[key]](
+		CreateCmpRange(cmp, key{"a", []byte{1}}, key{"a", []byte{5}}),
+		CreateCmpRange(cmp, key{"a", []byte{3}}, key{"b", nil}),
+	)
+	if set.Len() != 1 || !set.Contains(key{"a", []byte{9, 9}}) || set.Contains(key{"b", nil}) || set.Contains(key{"a", nil}) {
+		t.Errorf("RangeSet: got %s", set.String())
+	}
+	rest := set.Remove(CreateCmpRange(cmp, key{"a", []byte{2}}, key{"a", []byte{4}}))
+	if rest.Len() != 2 || !rest.Contains(key{"a", []byte{1, 5}}) || rest.Contains(key{"a", []byte{3}}) {
+		t.Errorf("Remove: got %s", rest.String())
+	}
+}
+
+func TestCmpRangeUnbounded(t *testing.T) {
+	//无界端点的值是nil，不能传给(*big.Rat).Cmp
+	cmp := (*big.Rat).Cmp
+	all := CreateUnboundedCmpRange(cmp)
+	to := CreateCmpRangeTo(cmp, big.NewRat(5, 1), Open)
+	from := CreateCmpRangeFrom(cmp, big.NewRat(1, 1), Closed)
+	middle := CreateCmpRange(cmp, big.NewRat(2, 1), big.NewRat(3, 1))
+	if r1, r2 := all.Except(middle); r1.String() != "(-∞,2/1)" || r2.String() != "[3/1,+∞)" {
+		t.Errorf("Except: got %s and %s", r1.String(), r2.String())
+	}
+	if r1, r2 := to.Except(all); !r1.IsEmpty() || !r2.IsEmpty() {
+		t.Errorf("Except: got %s and %s", r1.String(), r2.String())
+	}
+	if r1, r2 := all.Except(all); !r1.IsEmpty() || !r2.IsEmpty() || r1.IsIncludedPoint(big.NewRat(1, 1)) || !r1.Equal(middle.Range(big.NewRat(7, 1), big.NewRat(7, 1))) {
+		t.Errorf("(-∞,+∞)-(-∞,+∞): got %s and %s", r1.String(), r2.String())
+	}
+	if ok, r := to.Intersect(CreateCmpRange(cmp, big.NewRat(6, 1), big.NewRat(7, 1))); ok || !r.IsEmpty() {
+		t.Errorf("Intersect of disjoint ranges: got %s, %v", r.String(), ok)
+	}
+	if ok, r := to.Intersect(from); !ok || r.String() != "[1/1,5/1)" {
+		t.Errorf("Intersect: got %s, %v", r.String(), ok)
+	}
+	if ok, r := from.Union(to); !ok || r.String() != "(-∞,+∞)" || !r.Equal(all) {
+		t.Errorf("Union: got %s, %v", r.String(), ok)
+	}
+	if ok, r := all.IntersectOthers(nil); ok || !r.IsEmpty() {
+		t.Errorf("IntersectOthers(nil): got %s, %v", r.String(), ok)
+	}
+	empty, _ := all.Except(all)
+	if rest, _ := all.Except(empty); empty.IsIntersected(all) || !rest.Equal(all) || !empty.IsBefore(from) {
+		t.Errorf("operations on an empty range: wrong result")
+	}
+	if ok, r := empty.Union(from); !ok || !r.Equal(from) {
+		t.Errorf("empty+%s: got %s", from.String(), r.String())
+	}
+	set := NewRangeSet[*big.Rat, CmpRange[*big.Rat]](to, CreateCmpRangeFrom(cmp, big.NewRat(8, 1), Open)).Remove(middle)
+	if set.String() != "{(-∞,2/1),[3/1,5/1),(8/1,+∞)}" || set.Contains(big.NewRat(6, 1)) || !set.Contains(big.NewRat(100, 1)) {
+		t.Errorf("RangeSet: got %s", set.String())
+	}
+	if gaps := set.Complement(all); gaps.String() != "{[2/1,3/1),[5/1,8/1]}" {
+		t.Errorf("Complement: got %s", gaps.String())
+	}
+}
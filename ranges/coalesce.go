@@ -0,0 +1,80 @@
+package ranges
+
+import "errors"
+
+//ErrUnsortedRange是Coalescer在区间没有按照起点的先后顺序加入时返回的错误。
+var ErrUnsortedRange = errors.New("ranges: range starts before the ranges already added")
+
+//Coalesce函数将一组区间（rs）按照起点排序，并合并其中相交或相邻的区间，返回有序、互不相交且互不相邻的区间，空区间会被忽略。
+//与UnionOthers函数不同，Coalesce保留了区间之间的空隙，比如，[1,3)、[5,7)、[2,4)合并之后的结果是[1,4)与[5,7)。
+//Coalesce的时间复杂度是O(n log n)，排序之后的合并是线性的。给定的区间切片不会被修改。
+func Coalesce[P any, R any](rs []R) []R {
+	return normalize[P, R](rs)
+}
+
+//MergeSorted函数合并一组已经按照起点排序的区间（rs）中相交或相邻的区间，返回有序、互不相交且互不相邻的区间，
+//空区间会被忽略。MergeSorted的时间复杂度是O(n)，如果rs没有按照起点排序，结果中可能含有相交或相邻的区间，
+//此时应该使用Coalesce函数。给定的区间切片不会被修改。
+func MergeSorted[P any, R any](rs []R) []R {
+	var result []R
+	c := NewCoalescer[P, R](func(r R) {
+		result = append(result, r)
+	})
+	for _, r := range rs {
+		c.merge(typeTo[R, Range[P, R]](r))
+	}
+	c.Flush()
+	return result
+}
+
+//Coalescer是流式的区间合并器，区间按照起点的先后顺序一个一个地加入，相交或相邻的区间被合并在一起，
+//一旦后加入的区间与当前正在合并的区间之间出现空隙，当前区间就不会再变化，合并器立即把它交给emit函数，
+//因而，合并器只需要保存一个区间，适合于合并数据量很大或者逐条到达的区间，比如，从数据库中按时间顺序读出的故障记录。
+//所有区间都加入之后，需要调用Flush方法输出最后一个区间。
+//Coalescer不是线程安全类型，请注意不要在多线程环境下使用。
+type Coalescer[P any, R any] struct {
+	emit    func(R)     //接收合并结果的函数
+	current Range[P, R] //当前正在合并的区间，nil表示没有
+}
+
+//NewCoalescer函数用给定的接收函数emit构造一个区间合并器，并返回其指针。
+//合并的结果按照起点的先后顺序交给emit，它们互不相交且互不相邻。
+func NewCoalescer[P any, R any](emit func(R)) *Coalescer[P, R] {
+	return &Coalescer[P, R]{emit: emit}
+}
+
+//Add方法向合并器中加入区间r，空区间会被忽略。区间r的起点不能在当前正在合并的区间的起点之前，
+//否则r可能与已经输出的区间相交或相邻，此时Add方法返回ErrUnsortedRange，并且不加入r。
+func (c *Coalescer[P, R]) Add(r R) error {
+	rr := typeTo[R, Range[P, R]](r)
+	if c.current != nil && !IsEmpty(rr) && startsBefore(rr, c.current) {
+		return ErrUnsortedRange
+	}
+	c.merge(rr)
+	return nil
+}
+
+//Flush方法输出当前正在合并的区间，之后，合并器可以继续接收新的区间，新区间的起点不再受到限制。
+func (c *Coalescer[P, R]) Flush() {
+	if c.current != nil {
+		c.emit(typeTo[Range[P, R], R](c.current))
+		c.current = nil
+	}
+}
+
+//merge方法将区间r合并到当前区间中，如果二者之间有空隙，则输出当前区间，r成为新的当前区间。
+func (c *Coalescer[P, R]) merge(r Range[P, R]) {
+	if IsEmpty(r) {
+		return
+	}
+	if c.current == nil {
+		c.current = r
+		return
+	}
+	if isSuccessive, union := Union(c.current, r); isSuccessive {
+		c.current = typeTo[R, Range[P, R]](union)
+		return
+	}
+	c.emit(typeTo[Range[P, R], R](c.current))
+	c.current = r
+}
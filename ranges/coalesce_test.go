@@ -0,0 +1,73 @@
+package ranges
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoalesce(t *testing.T) {
+	nr := CreateNumberRangeWithBounds[int]
+	rs := []NumberRange[int]{
+		CreateNumberRange(5, 7), CreateNumberRange(1, 3), CreateNumberRange(0, 0),
+		CreateNumberRange(2, 4), nr(7, 8, Open, Closed), CreateNumberRange(4, 5), CreateNumberRange(10, 12),
+	}
+	got := Coalesce[int, NumberRange[int]](rs)
+	if s := NewRangeSet[int, NumberRange[int]](got...).String(); len(got) != 3 || s != "{[1,7),[8,9),[10,12)}" {
+		t.Errorf("Coalesce: got %s", s)
+	}
+	if rs[0].String() != "[5,7)" {
+		t.Errorf("Coalesce should not modify its input")
+	}
+	if got := Coalesce[int, NumberRange[int]](nil); len(got) != 0 {
+		t.Errorf("Coalesce(nil) = %v", got)
+	}
+	sorted := []NumberRange[int]{CreateNumberRange(1, 3), CreateNumberRange(2, 4), CreateNumberRange(4, 4), CreateNumberRange(4, 6), CreateNumberRange(8, 9)}
+	if got := MergeSorted[int, NumberRange[int]](sorted); len(got) != 2 || got[0].String() != "[1,6)" || got[1].String() != "[8,9)" {
+		t.Errorf("MergeSorted: got %v", got)
+	}
+}
+
+func TestCoalescer(t *testing.T) {
+	var emitted []string
+	c := NewCoalescer[int, NumberRange[int]](func(r NumberRange[int]) {
+		emitted = append(emitted, r.String())
+	})
+	for _, r := range []NumberRange[int]{CreateNumberRange(1, 3), CreateNumberRange(3, 4), CreateNumberRange(2, 5)} {
+		if err := c.Add(r); err != nil {
+			t.Fatalf("Add(%s): %v", r.String(), err)
+		}
+	}
+	if len(emitted) != 0 {
+		t.Errorf("nothing should be emitted before a gap, got %v", emitted)
+	}
+	if err := c.Add(CreateNumberRange(7, 9)); err != nil || len(emitted) != 1 || emitted[0] != "[1,5)" {
+		t.Errorf("Add([7,9)): got %v, %v", emitted, err)
+	}
+	if err := c.Add(CreateNumberRange(6, 8)); err != ErrUnsortedRange {
+		t.Errorf("Add([6,8)) should fail, got %v", err)
+	}
+	if err := c.Add(CreateNumberRange(0, 0)); err != nil {
+		t.Errorf("an empty range should be ignored, got %v", err)
+	}
+	c.Flush()
+	c.Flush()
+	if len(emitted) != 2 || emitted[1] != "[7,9)" {
+		t.Errorf("Flush: got %v", emitted)
+	}
+}
+
+func TestCoalesceLarge(t *testing.T) {
+	//一年的15分钟故障记录，每天有一个小时没有记录，合并之后每天一个区间
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var records []TimeInterval
+	for day := 364; day >= 0; day-- {
+		for slot := 4; slot < 96; slot++ {
+			s := start.Add(time.Duration(day)*24*time.Hour + time.Duration(slot)*15*time.Minute)
+			records = append(records, CreateTimeInterval(s, s.Add(15*time.Minute)))
+		}
+	}
+	got := Coalesce[time.Time, TimeInterval](records)
+	if len(got) != 365 || Tintvl2Str(got[0]) != "[2022-01-01 01:00:00,2022-01-02 00:00:00)" {
+		t.Errorf("Coalesce: got %d ranges starting with %s", len(got), Tintvl2Str(got[0]))
+	}
+}
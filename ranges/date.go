@@ -0,0 +1,293 @@
+package ranges
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//DATE_LAYOUT是日期的文本格式，比如，2022-01-31。
+const DATE_LAYOUT = "2006-01-02"
+
+//Date定义了不含时刻与时区的日历日期，比如，合同与账单的起止日期。
+//与time.Time不同，Date是可以直接使用==比较的值，同一个日期在任何时区都是相同的。
+//Date满足Sequencable[Date]约束，可以作为SeqRange的端点，参见DateRange。
+//零值的Date不是有效的日历日期，应使用CreateDate、DateOf或ParseDate创建日期。
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+//CreateDate函数用年、月、日创建一个日期，超出范围的月与日会像time.Date一样被规范化，比如，2022-02-30被规范化为2022-03-02。
+func CreateDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+//DateOf函数返回时间t在其自身时区中的日期。需要其他时区的日期时，可以先转换时区，比如，DateOf(t.In(loc))。
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+//ParseDate函数解析“2006-01-02”格式的日期文本，也就是String方法的输出。
+//0至9999年以外的年份使用ISO 8601的扩展形式，带有正负号，并且至少有4位数字，比如，“+10000-01-01”、“-0001-12-31”。
+func ParseDate(s string) (Date, error) {
+	text, sign := s, 1
+	if text != "" && (text[0] == '+' || text[0] == '-') {
+		if text[0] == '-' {
+			sign = -1
+		}
+		text = text[1:]
+	}
+	//年份之后是“-01-02”形式的月与日
+	n := len(text) - len("-01-02")
+	if n < 4 || n > 4 && len(text) == len(s) || text[n] != '-' || text[n+3] != '-' ||
+		!isDigits(text[:n]) || !isDigits(text[n+1:n+3]) || !isDigits(text[n+4:]) {
+		return Date{}, &ParseError{Text: s, Msg: "date must be in the form 2006-01-02"}
+	}
+	year, err := strconv.Atoi(text[:n])
+	if err != nil {
+		return Date{}, &ParseError{Text: s, Msg: "invalid year", Err: err}
+	}
+	month, _ := strconv.Atoi(text[n+1 : n+3])
+	day, _ := strconv.Atoi(text[n+4:])
+	d := Date{Year: sign * year, Month: time.Month(month), Day: day}
+	if CreateDate(d.Year, d.Month, d.Day) != d {
+		return Date{}, &ParseError{Text: s, Msg: "invalid date"}
+	}
+	return d, nil
+}
+
+//Equal方法判断两个日期是否相同。
+func (d Date) Equal(other Date) bool {
+	return d == other
+}
+
+//Before方法判断日期d是否在other之前。
+func (d Date) Before(other Date) bool {
+	if d.Year != other.Year {
+		return d.Year < other.Year
+	}
+	if d.Month != other.Month {
+		return d.Month < other.Month
+	}
+	return d.Day < other.Day
+}
+
+//After方法判断日期d是否在other之后。
+func (d Date) After(other Date) bool {
+	return other.Before(d)
+}
+
+//AddDays方法返回日期d之后第n天的日期，n为负数时返回之前的日期。
+func (d Date) AddDays(n int) Date {
+	return CreateDate(d.Year, d.Month, d.Day+n)
+}
+
+//AddDate方法返回日期d加上给定的年、月、日之后的日期，规范化规则与time.Time的AddDate方法相同，
+//比如，2022-01-31加上一个月是2022-03-03。
+func (d Date) AddDate(years, months, days int) Date {
+	return CreateDate(d.Year+years, d.Month+time.Month(months), d.Day+days)
+}
+
+//Sub方法返回日期d与other相差的天数，d在other之前时结果为负数。
+func (d Date) Sub(other Date) int {
+	return int(d.unixDay() - other.unixDay())
+}
+
+//Weekday方法返回日期是星期几。
+func (d Date) Weekday() time.Weekday {
+	return d.midnight(time.UTC).Weekday()
+}
+
+//In方法返回日期d在时区loc中开始的时刻，通常是当天的00:00。
+//如果当地时间00:00因夏令时而不存在，结果是time.Date规范化之后的时刻。
+func (d Date) In(loc *time.Location) time.Time {
+	return d.midnight(loc)
+}
+
+//String方法使用“2006-01-02”格式输出日期，0至9999年以外的年份带有正负号，并且至少有4位数字，
+//比如，“+10000-01-01”、“-0001-12-31”，ParseDate函数可以解析String方法输出的所有日期。
+func (d Date) String() string {
+	if d.Year >= 0 && d.Year <= 9999 {
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+	}
+	return fmt.Sprintf("%+05d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+//MarshalText方法将日期编码为“2006-01-02”格式的文本，因而，日期编码为JSON字符串，比如，"2022-01-31"。
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalText方法解析“2006-01-02”格式的日期文本。
+func (d *Date) UnmarshalText(text []byte) error {
+	result, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = result
+	return nil
+}
+
+//isDigits函数判断文本是否是非空的十进制数字串。
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func (d Date) midnight(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+//unixDay方法返回日期距离1970-01-01的天数。
+func (d Date) unixDay() int64 {
+	return d.midnight(time.UTC).Unix() / (24 * 60 * 60)
+}
+
+//DateRange是由日期构成的区间，比如，合同期[2022-01-01,2022-12-31]。
+//DateRange编码为JSON时，日期使用“2006-01-02”格式，比如，{"start":"2022-01-01","end":"2023-01-01"}。
+//与IntRange不同，DateRange是SeqRange的实例，不会自动化为规范形式，因而，包含相同日期的[2022-01-01,2022-01-31]
+//与[2022-01-01,2022-02-01)互不相等，(2022-01-01,2022-01-02)不是空区间，尽管它不包含任何一天。
+//需要按照包含的日期比较或者判断是否为空时，先用CanonicalDateRange函数化为规范形式。
+type DateRange = SeqRange[Date, Date]
+
+//CreateDateRange函数用给定的两个日期创建一个左闭右开的日期区间。
+func CreateDateRange(d1, d2 Date) DateRange {
+	return CreateSeqRange[Date, Date](d1, d2)
+}
+
+//CreateDateRangeWithBounds函数用给定的两个日期及其边界类型创建一个日期区间，比如，两端都是闭边界的合同期。
+func CreateDateRangeWithBounds(d1, d2 Date, left, right BoundType) DateRange {
+	return CreateSeqRangeWithBounds[Date, Date](d1, d2, left, right)
+}
+
+/**
+与TimeInterval相同，DateRange是由泛型类型SeqRange实例化产生的类型，不能定义自己的方法，
+所以，定义若干函数来操作DateRange。
+**/
+
+//DateRangeDays函数返回日期区间包含的天数，比如，[2022-01-01,2022-01-31]包含31天。
+//起点或者终点无界时，天数是无限的，返回-1。
+func DateRangeDays(dr DateRange) int {
+	first, after, ok := dateSpan(dr)
+	if !ok {
+		return -1
+	}
+	if after.Before(first) {
+		return 0
+	}
+	return after.Sub(first)
+}
+
+//CanonicalDateRange函数将日期区间化为包含相同日期的规范形式：有界的起点是闭边界，有界的终点是开边界，
+//比如，[2022-01-01,2022-01-31]与(2021-12-31,2022-02-01)都被化为[2022-01-01,2022-02-01)，
+//不包含任何一天的区间，比如，(2022-01-01,2022-01-02)，被化为空区间。无界的端点保持无界。
+//因而，包含相同日期的两个区间化为规范形式之后一定相等。
+func CanonicalDateRange(dr DateRange) DateRange {
+	start, end := dr.DeRange()
+	left, right := dr.Bounds()
+	if left != Unbounded {
+		start, left = firstDate(dr), Closed
+	}
+	if right != Unbounded {
+		end, right = afterDate(dr), Open
+	}
+	if left != Unbounded && right != Unbounded && !start.Before(end) {
+		return DateRange{}
+	}
+	return CreateDateRangeWithBounds(start, end, left, right)
+}
+
+//WalkDates函数从早到晚依次使用函数f访问日期区间包含的每一天，f返回false时停止访问。
+//终点无界时，访问会一直持续到f返回false为止；起点无界时，没有开始访问的日期，f不会被调用。
+func WalkDates(dr DateRange, f func(d Date) bool) {
+	if dr.IsEmpty() {
+		return
+	}
+	left, right := dr.Bounds()
+	if left == Unbounded {
+		return
+	}
+	for d := firstDate(dr); right == Unbounded || dr.IsIncludedPoint(d); d = d.AddDays(1) {
+		if !f(d) {
+			return
+		}
+	}
+}
+
+//DateRangeToTimeInterval函数将日期区间转换为时区loc中的时间段，时间段从第一天的开始时刻起，
+//到最后一天之后一天的开始时刻止，是左闭右开的，比如，在UTC时区中，[2022-01-01,2022-01-31]转换为
+//[2022-01-01T00:00:00Z,2022-02-01T00:00:00Z)。无界的端点转换为无界的端点。
+func DateRangeToTimeInterval(dr DateRange, loc *time.Location) TimeInterval {
+	if dr.IsEmpty() {
+		return TimeInterval{}
+	}
+	left, right := dr.Bounds()
+	var start, end time.Time
+	if left != Unbounded {
+		start, left = firstDate(dr).In(loc), Closed
+	}
+	if right != Unbounded {
+		end, right = afterDate(dr).In(loc), Open
+	}
+	return CreateTimeIntervalWithBounds(start, end, left, right)
+}
+
+//DateRangeOf函数返回时间段ti在时区loc中涉及的所有日期构成的左闭右开的日期区间，也就是时间段中的时刻在时区loc中的日期。
+//比如，在UTC时区中，[2022-01-01T08:00:00Z,2022-01-03T00:00:00Z)涉及的日期是[2022-01-01,2022-01-03)，
+//而[2022-01-01T08:00:00Z,2022-01-03T00:00:00Z]还涉及2022-01-03。
+//结果总是规范形式，所以，对于任何日期区间dr，DateRangeOf(DateRangeToTimeInterval(dr, loc), loc)与CanonicalDateRange(dr)相等，
+//比如，[2022-01-01,2022-01-31]往返之后是[2022-01-01,2022-02-01)，二者包含相同的日期，但不是Equal的。
+func DateRangeOf(ti TimeInterval, loc *time.Location) DateRange {
+	if ti.IsEmpty() {
+		return DateRange{}
+	}
+	start, end := ti.DeRange()
+	left, right := ti.Bounds()
+	var first, after Date
+	if left != Unbounded {
+		first, left = DateOf(start.In(loc)), Closed
+	}
+	if right != Unbounded {
+		end = end.In(loc)
+		after = DateOf(end)
+		if right == Closed || !end.Equal(after.In(loc)) {
+			after = after.AddDays(1)
+		}
+		right = Open
+	}
+	return CreateDateRangeWithBounds(first, after, left, right)
+}
+
+//dateSpan函数返回日期区间的第一天以及最后一天之后的一天，有端点无界时ok为false。
+func dateSpan(dr DateRange) (first, after Date, ok bool) {
+	left, right := dr.Bounds()
+	if left == Unbounded || right == Unbounded {
+		return
+	}
+	return firstDate(dr), afterDate(dr), true
+}
+
+//firstDate函数返回起点有界的日期区间的第一天。
+func firstDate(dr DateRange) Date {
+	start, _ := dr.DeRange()
+	if left, _ := dr.Bounds(); left == Open {
+		return start.AddDays(1)
+	}
+	return start
+}
+
+//afterDate函数返回终点有界的日期区间的最后一天之后的一天。
+func afterDate(dr DateRange) Date {
+	_, end := dr.DeRange()
+	if _, right := dr.Bounds(); right == Closed {
+		return end.AddDays(1)
+	}
+	return end
+}
@@ -0,0 +1,144 @@
+package ranges
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	d := CreateDate(2022, 2, 30)
+	if d != (Date{2022, 3, 2}) || d.String() != "2022-03-02" {
+		t.Errorf("CreateDate should normalize, got %s", d)
+	}
+	if p, err := ParseDate("2022-03-02"); err != nil || p != d {
+		t.Errorf("ParseDate: got %s, %v", p, err)
+	}
+	for _, text := range []string{"2022-02-30", "2022-1-01", "22-01-01", "20220-01-01", "+2022-13-01", "-1-01-01", "2022/01/01", "", "+", "2022-01-01x"} {
+		if _, err := ParseDate(text); err == nil {
+			t.Errorf("ParseDate(%q) should fail", text)
+		}
+	}
+	//0至9999年以外的日期也可以经过文本往返
+	for _, d := range []Date{{10000, 1, 1}, {-1, 12, 31}, {0, 1, 1}, {9999, 12, 31}, {-12345, 2, 28}, {2024, 2, 29}} {
+		text, _ := d.MarshalText()
+		var back Date
+		if err := back.UnmarshalText(text); err != nil || back != d {
+			t.Errorf("the round trip of %v via %s: got %v, %v", d, text, back, err)
+		}
+	}
+	if s := (Date{10000, 1, 1}).String() + " " + (Date{-1, 12, 31}).String(); s != "+10000-01-01 -0001-12-31" {
+		t.Errorf("String of years outside 0..9999: got %s", s)
+	}
+	if !d.Before(CreateDate(2022, 3, 3)) || !d.After(CreateDate(2021, 12, 31)) || d.Before(d) || !d.Equal(Date{2022, 3, 2}) {
+		t.Errorf("Before and After: wrong result")
+	}
+	if n := CreateDate(2023, 1, 1).Sub(CreateDate(2022, 1, 1)); n != 365 {
+		t.Errorf("Sub: got %d", n)
+	}
+	if got := CreateDate(2022, 1, 31).AddDate(0, 1, 0); got != CreateDate(2022, 3, 3) {
+		t.Errorf("AddDate: got %s", got)
+	}
+	if got := CreateDate(1969, 12, 31).AddDays(2); got != CreateDate(1970, 1, 2) || CreateDate(1969, 12, 31).Sub(got) != -2 {
+		t.Errorf("AddDays across the epoch: got %s", got)
+	}
+	//同一个时刻在不同时区中是不同的日期
+	shanghai := time.FixedZone("CST", 8*3600)
+	instant := time.Date(2022, 1, 1, 20, 0, 0, 0, time.UTC)
+	if DateOf(instant) != CreateDate(2022, 1, 1) || DateOf(instant.In(shanghai)) != CreateDate(2022, 1, 2) {
+		t.Errorf("DateOf: wrong result")
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	jan1, jan31 := CreateDate(2022, 1, 1), CreateDate(2022, 1, 31)
+	contract := CreateDateRangeWithBounds(jan1, jan31, Closed, Closed)
+	if n := DateRangeDays(contract); n != 31 {
+		t.Errorf("DateRangeDays: got %d", n)
+	}
+	if n := DateRangeDays(CreateDateRangeWithBounds(jan1, jan1.AddDays(1), Open, Open)); n != 0 {
+		t.Errorf("DateRangeDays of (d,d+1): got %d", n)
+	}
+	if n := DateRangeDays(CreateSeqRangeFrom[Date, Date](jan1, Closed)); n != -1 {
+		t.Errorf("DateRangeDays of an unbounded range: got %d", n)
+	}
+	var days []Date
+	WalkDates(CreateDateRangeWithBounds(jan1, CreateDate(2022, 1, 4), Open, Closed), func(d Date) bool {
+		days = append(days, d)
+		return true
+	})
+	if len(days) != 3 || days[0] != CreateDate(2022, 1, 2) || days[2] != CreateDate(2022, 1, 4) {
+		t.Errorf("WalkDates: got %v", days)
+	}
+	n := 0
+	WalkDates(CreateSeqRangeFrom[Date, Date](jan1, Closed), func(d Date) bool {
+		n++
+		return n < 10
+	})
+	if n != 10 {
+		t.Errorf("WalkDates should stop when f returns false, got %d days", n)
+	}
+	for _, dr := range []DateRange{
+		contract, CreateDateRange(jan1, CreateDate(2022, 2, 1)), CreateDateRangeWithBounds(CreateDate(2021, 12, 31), CreateDate(2022, 2, 1), Open, Open),
+	} {
+		if c := CanonicalDateRange(dr); c.String() != "[2022-01-01,2022-02-01)" || DateRangeDays(c) != 31 {
+			t.Errorf("CanonicalDateRange(%s) = %s", dr.String(), c.String())
+		}
+	}
+	if c := CanonicalDateRange(CreateDateRangeWithBounds(jan1, jan1.AddDays(1), Open, Open)); !c.IsEmpty() {
+		t.Errorf("CanonicalDateRange of (d,d+1) should be empty, got %s", c.String())
+	}
+	if c := CanonicalDateRange(CreateSeqRangeTo[Date, Date](jan31, Closed)); c.String() != "(-∞,2022-02-01)" {
+		t.Errorf("CanonicalDateRange of an unbounded range: got %s", c.String())
+	}
+	if s := contract.String(); s != "[2022-01-01,2022-01-31]" {
+		t.Errorf("String: got %s", s)
+	}
+	data, err := json.Marshal(contract)
+	if err != nil || string(data) != `{"start":"2022-01-01","end":"2022-01-31","bounds":"[]"}` {
+		t.Errorf("MarshalJSON: got %s, %v", data, err)
+	}
+	var decoded DateRange
+	if err := json.Unmarshal([]byte(`"[2022-01-01,2022-01-31]"`), &decoded); err != nil || !decoded.Equal(contract) {
+		t.Errorf("UnmarshalJSON: got %s, %v", decoded.String(), err)
+	}
+}
+
+func TestDateRangeTimeInterval(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	//2022-03-13是纽约夏令时开始的日期，当天只有23小时
+	march := CreateDateRangeWithBounds(CreateDate(2022, 3, 13), CreateDate(2022, 3, 13), Closed, Closed)
+	ti := DateRangeToTimeInterval(march, ny)
+	start, end := ti.DeRange()
+	if !start.Equal(time.Date(2022, 3, 13, 0, 0, 0, 0, ny)) || end.Sub(start) != 23*time.Hour {
+		t.Errorf("DateRangeToTimeInterval: got %s", Tintvl2Str(ti))
+	}
+	if dr := DateRangeOf(ti, ny); !dr.Equal(CanonicalDateRange(march)) || dr.String() != "[2022-03-13,2022-03-14)" {
+		t.Errorf("DateRangeOf should reverse DateRangeToTimeInterval, got %s", dr.String())
+	}
+	contract := CreateDateRangeWithBounds(CreateDate(2022, 1, 1), CreateDate(2022, 1, 31), Closed, Closed)
+	for _, loc := range []*time.Location{time.UTC, ny} {
+		if dr := DateRangeOf(DateRangeToTimeInterval(contract, loc), loc); !dr.Equal(CanonicalDateRange(contract)) || dr.Equal(contract) {
+			t.Errorf("the round trip of %s in %s: got %s", contract.String(), loc, dr.String())
+		}
+	}
+	//同一个时间段在UTC时区中涉及两天
+	if dr := DateRangeOf(ti, time.UTC); DateRangeDays(dr) != 2 {
+		t.Errorf("DateRangeOf in UTC: got %s", dr.String())
+	}
+	instant := time.Date(2022, 1, 1, 8, 0, 0, 0, time.UTC)
+	midnight := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	if dr := DateRangeOf(CreateTimeInterval(instant, midnight), time.UTC); dr.String() != "[2022-01-01,2022-01-03)" {
+		t.Errorf("DateRangeOf: got %s", dr.String())
+	}
+	if dr := DateRangeOf(CreateTimeIntervalWithBounds(instant, midnight, Closed, Closed), time.UTC); dr.String() != "[2022-01-01,2022-01-04)" {
+		t.Errorf("DateRangeOf with a closed end: got %s", dr.String())
+	}
+	from := DateRangeToTimeInterval(CreateSeqRangeFrom[Date, Date](CreateDate(2022, 1, 1), Open), time.UTC)
+	if s := Tintvl2Str(from); s != "[2022-01-02 00:00:00,+∞)" {
+		t.Errorf("DateRangeToTimeInterval with an unbounded end: got %s", s)
+	}
+}
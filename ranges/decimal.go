@@ -0,0 +1,164 @@
+package ranges
+
+import (
+	"math/big"
+	"strings"
+)
+
+//Decimal定义了精确的十进制数（更一般地，有理数），用于金额、电量等不能有浮点误差的量，比如，0.1+0.2精确地等于0.3。
+//Decimal内部保存有理数的规范文本形式，运算时使用*big.Rat，是不可变的值类型：所有运算都返回新的Decimal，
+//而不会修改原有的值，因而，可以像数字一样复制与传递Decimal。
+//Decimal满足Sequencable[Decimal]约束，可以作为SeqRange的端点，参见DecimalRange。
+//由于内部形式是规范的，==与Equal方法的结果相同，比如，0.50与1/2相等，Decimal也可以作为map的键。零值的Decimal是0。
+type Decimal struct {
+	rat string //最简分数形式，比如，“1/2”、“-3”，空字符串表示0
+}
+
+//DecimalOf函数用整数n创建一个Decimal。
+func DecimalOf(n int64) Decimal {
+	return newDecimal(new(big.Rat).SetInt64(n))
+}
+
+//DecimalOfRat函数用有理数r创建一个Decimal，Decimal不保存r，此后修改r不会影响Decimal。
+func DecimalOfRat(r *big.Rat) Decimal {
+	return newDecimal(r)
+}
+
+//ParseDecimal函数解析十进制数或者分数的文本，比如，“12.345”、“-0.5”、“1e-3”、“1/3”。
+func ParseDecimal(s string) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Decimal{}, &ParseError{Text: s, Msg: "invalid decimal"}
+	}
+	return newDecimal(r), nil
+}
+
+//Rat方法返回与Decimal相等的有理数，返回的是一个副本，修改它不会影响Decimal。
+func (d Decimal) Rat() *big.Rat {
+	return d.value()
+}
+
+//Add方法返回d+other。
+func (d Decimal) Add(other Decimal) Decimal {
+	return newDecimal(new(big.Rat).Add(d.value(), other.value()))
+}
+
+//Sub方法返回d-other。
+func (d Decimal) Sub(other Decimal) Decimal {
+	return newDecimal(new(big.Rat).Sub(d.value(), other.value()))
+}
+
+//Mul方法返回d*other。
+func (d Decimal) Mul(other Decimal) Decimal {
+	return newDecimal(new(big.Rat).Mul(d.value(), other.value()))
+}
+
+//MulInt方法返回d*n。
+func (d Decimal) MulInt(n int64) Decimal {
+	return d.Mul(DecimalOf(n))
+}
+
+//Quo方法返回d/other，结果是精确的分数，比如，1/3。other为0时会panic。
+func (d Decimal) Quo(other Decimal) Decimal {
+	return newDecimal(new(big.Rat).Quo(d.value(), other.value()))
+}
+
+//Neg方法返回-d。
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Rat).Neg(d.value()))
+}
+
+//Cmp方法比较d与other的大小，d小于other返回-1，d大于other返回1，二者相等返回0。
+func (d Decimal) Cmp(other Decimal) int {
+	return d.value().Cmp(other.value())
+}
+
+//Sign方法返回d的符号，d小于0返回-1，等于0返回0，大于0返回1。
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+//IsZero方法判断d是否等于0。
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+//Equal方法判断d与other是否相等。
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+//Before方法判断d是否小于other。
+func (d Decimal) Before(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+//After方法判断d是否大于other。
+func (d Decimal) After(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+//FloatString方法将d四舍五入到小数点后prec位，比如，用于显示金额。
+func (d Decimal) FloatString(prec int) string {
+	return d.value().FloatString(prec)
+}
+
+//String方法输出d的精确文本形式：能够精确表示为有限小数的，输出十进制小数，比如，“12.345”、“-0.5”、“3”；
+//否则输出分数，比如，“1/3”。
+func (d Decimal) String() string {
+	r := d.value()
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	//分母只有因子2与5时，可以精确表示为有限小数，小数位数是因子2与因子5的个数中较大的一个
+	denom := new(big.Int).Set(r.Denom())
+	twos, fives := 0, 0
+	two, five, rem := big.NewInt(2), big.NewInt(5), new(big.Int)
+	for rem.Rem(denom, two).Sign() == 0 {
+		denom.Quo(denom, two)
+		twos++
+	}
+	for rem.Rem(denom, five).Sign() == 0 {
+		denom.Quo(denom, five)
+		fives++
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return r.String()
+	}
+	if twos < fives {
+		twos = fives
+	}
+	return r.FloatString(twos)
+}
+
+//MarshalText方法将d编码为精确的文本，参见String方法，因而，Decimal编码为JSON字符串，比如，"12.345"。
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalText方法解析十进制数或者分数的文本，参见ParseDecimal。
+func (d *Decimal) UnmarshalText(text []byte) error {
+	result, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = result
+	return nil
+}
+
+//newDecimal函数用有理数r创建一个Decimal，r不会被保存或者修改。
+func newDecimal(r *big.Rat) Decimal {
+	if r.Sign() == 0 {
+		return Decimal{}
+	}
+	return Decimal{rat: r.RatString()}
+}
+
+//value方法返回与d相等的有理数，零值的Decimal返回0。
+func (d Decimal) value() *big.Rat {
+	r := new(big.Rat)
+	if d.rat != "" {
+		r.SetString(d.rat)
+	}
+	return r
+}
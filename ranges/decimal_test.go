@@ -0,0 +1,80 @@
+package ranges
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	cases := map[string]string{
+		"12.345": "12.345", "-0.5": "-0.5", "1e-3": "0.001", "3": "3", "1/3": "1/3",
+		"2/4": "0.5", " 0.10 ": "0.1", "-7/8": "-0.875", "1/6": "1/6",
+	}
+	for text, want := range cases {
+		d, err := ParseDecimal(text)
+		if err != nil || d.String() != want {
+			t.Errorf("ParseDecimal(%q) = %s, %v, want %s", text, d, err, want)
+		}
+	}
+	for _, text := range []string{"", "abc", "1/0", "1.2.3"} {
+		if _, err := ParseDecimal(text); err == nil {
+			t.Errorf("ParseDecimal(%q) should fail", text)
+		}
+	}
+
+	a, _ := ParseDecimal("0.1")
+	b, _ := ParseDecimal("0.2")
+	c, _ := ParseDecimal("0.3")
+	if !a.Add(b).Equal(c) || a.Add(b).String() != "0.3" {
+		t.Errorf("0.1+0.2 = %s", a.Add(b))
+	}
+	third := DecimalOf(1).Quo(DecimalOf(3))
+	if !third.MulInt(3).Equal(DecimalOf(1)) || third.FloatString(4) != "0.3333" {
+		t.Errorf("1/3*3 = %s, 1/3 = %s", third.MulInt(3), third.FloatString(4))
+	}
+	var zero Decimal
+	if !zero.IsZero() || zero.String() != "0" || !zero.Add(a).Equal(a) || zero.Sign() != 0 {
+		t.Errorf("the zero Decimal should be 0")
+	}
+	if !a.Before(b) || !b.After(a) || a.Neg().Sign() != -1 || !c.Sub(b).Equal(a) {
+		t.Errorf("Decimal comparison failed")
+	}
+
+	//值语义：修改Rat返回的副本或者传给DecimalOfRat的有理数，不影响Decimal
+	r := big.NewRat(1, 4)
+	d := DecimalOfRat(r)
+	r.SetInt64(5)
+	d.Rat().SetInt64(7)
+	if d.String() != "0.25" {
+		t.Errorf("Decimal should not share its value, got %s", d)
+	}
+	e := d
+	_ = e.Add(DecimalOf(1))
+	if d.String() != "0.25" || e.String() != "0.25" {
+		t.Errorf("Add should not modify the receiver")
+	}
+
+	//规范形式：==与Equal的结果相同，Decimal可以作为map的键
+	half, _ := ParseDecimal("0.50")
+	if half != decimalOf("1/2") || DecimalOf(1) != DecimalOf(1) || zero != DecimalOf(0) || a.Add(b) != c || half == a {
+		t.Errorf("== should agree with Equal")
+	}
+	totals := map[Decimal]int{}
+	for _, text := range []string{"0.5", "1/2", "2/4", "0.25"} {
+		totals[decimalOf(text)]++
+	}
+	if len(totals) != 2 || totals[half] != 3 {
+		t.Errorf("Decimal as a map key: got %v", totals)
+	}
+
+	var v struct {
+		Price Decimal `json:"price"`
+	}
+	if err := json.Unmarshal([]byte(`{"price":"19.99"}`), &v); err != nil || v.Price.String() != "19.99" {
+		t.Errorf("UnmarshalJSON: got %s, %v", v.Price, err)
+	}
+	if data, err := json.Marshal(v); err != nil || string(data) != `{"price":"19.99"}` {
+		t.Errorf("MarshalJSON: got %s, %v", data, err)
+	}
+}
@@ -0,0 +1,124 @@
+package ranges
+
+//DecimalRange是由精确的十进制数构成的区间，比如，电价的计量区间[0,0.25)，运算中没有浮点误差。
+//DecimalRange编码为JSON时，端点使用精确的文本，比如，{"start":"0","end":"0.25"}。
+type DecimalRange = SeqRange[Decimal, Decimal]
+
+//CreateDecimalRange函数用给定的两个数创建一个左闭右开区间。
+func CreateDecimalRange(d1, d2 Decimal) DecimalRange {
+	return CreateSeqRange[Decimal, Decimal](d1, d2)
+}
+
+//CreateDecimalRangeWithBounds函数用给定的两个数及其边界类型创建一个区间。
+func CreateDecimalRangeWithBounds(d1, d2 Decimal, left, right BoundType) DecimalRange {
+	return CreateSeqRangeWithBounds[Decimal, Decimal](d1, d2, left, right)
+}
+
+/**
+与TimeInterval相同，DecimalRange是由泛型类型SeqRange实例化产生的类型，不能定义自己的方法，
+所以，定义若干函数来操作DecimalRange。
+**/
+
+//DecimalRangeLength函数返回区间的长度，也就是终点减去起点，空区间的长度是0。起点或者终点无界时，长度是无限的，返回false。
+func DecimalRangeLength(dr DecimalRange) (bool, Decimal) {
+	left, right := dr.Bounds()
+	if left == Unbounded || right == Unbounded {
+		return false, Decimal{}
+	}
+	if dr.IsEmpty() {
+		return true, Decimal{}
+	}
+	start, end := dr.DeRange()
+	return true, end.Sub(start)
+}
+
+//SplitDecimalRange函数将有界的区间等分为n个首尾相接的区间，比如，[0,1)等分为3个区间是[0,1/3)、[1/3,2/3)与[2/3,1)。
+//第一个区间沿用dr的起点边界类型，最后一个区间沿用dr的终点边界类型，中间的分割点属于后一个区间。
+//区间是空的或者无界的，或者n不是正数时，返回nil。
+func SplitDecimalRange(dr DecimalRange, n int) []DecimalRange {
+	ok, length := DecimalRangeLength(dr)
+	if !ok || dr.IsEmpty() || n <= 0 {
+		return nil
+	}
+	start, _ := dr.DeRange()
+	step := length.Quo(DecimalOf(int64(n)))
+	points := make([]Decimal, 0, n-1)
+	for i := 1; i < n; i++ {
+		//每个分割点都从起点直接计算，而不是逐个累加，尽管对于精确的十进制数，二者的结果相同
+		points = append(points, start.Add(step.MulInt(int64(i))))
+	}
+	return splitDecimalRangeAt(dr, points)
+}
+
+//SplitDecimalRangeBy函数将有界的区间从起点开始按照长度step分割为首尾相接的区间，最后一个区间的长度可能小于step，
+//比如，[0,1)按照0.3分割是[0,0.3)、[0.3,0.6)、[0.6,0.9)与[0.9,1)。区间的边界类型与SplitDecimalRange相同。
+//区间是空的或者无界的，或者step不是正数时，返回nil。
+func SplitDecimalRangeBy(dr DecimalRange, step Decimal) []DecimalRange {
+	ok, length := DecimalRangeLength(dr)
+	if !ok || dr.IsEmpty() || step.Sign() <= 0 {
+		return nil
+	}
+	start, _ := dr.DeRange()
+	var points []Decimal
+	for i := int64(1); step.MulInt(i).Before(length); i++ {
+		points = append(points, start.Add(step.MulInt(i)))
+	}
+	return splitDecimalRangeAt(dr, points)
+}
+
+//ProrateDecimal函数按照区间part与whole重叠部分的长度占whole长度的比例，分摊数量amount，结果是精确的，
+//比如，月费100元，按照使用的天数分摊，ProrateDecimal(100, [0,30), [0,10))是100/3。
+//whole是无界的或者长度为0时，返回false。part可以是无界的。
+func ProrateDecimal(amount Decimal, whole, part DecimalRange) (bool, Decimal) {
+	ok, wholeLength := DecimalRangeLength(whole)
+	if !ok || wholeLength.IsZero() {
+		return false, Decimal{}
+	}
+	_, overlap := whole.Intersect(part)
+	_, overlapLength := DecimalRangeLength(overlap)
+	return true, amount.Mul(overlapLength).Quo(wholeLength)
+}
+
+//DecimalCycle定义了十进制数的周期类型，是NumCycle的精确版本。
+type DecimalCycle struct {
+	Count int
+	Unit  Decimal
+}
+
+func (dc DecimalCycle) GetCount() int {
+	return dc.Count
+}
+func (dc DecimalCycle) GetUnit() Decimal {
+	return dc.Unit
+}
+
+//DRCycleFunc是DecimalRange的周期计算函数，是NRCycleFunc的精确版本。
+//第n个周期的区间由原始区间直接平移n*Count*Unit得到，没有累积误差，比如，每天96个15分钟（0.25小时）的区间，
+//第96个区间的终点精确地是24。
+type DRCycleFunc struct{}
+
+func (dc *DRCycleFunc) OfCycles(t DecimalRange, n int, c DecimalCycle) DecimalRange {
+	rStart, rEnd := t.DeRange()
+	amount := c.Unit.MulInt(int64(n) * int64(c.Count))
+	left, right := t.Bounds()
+	return t.RangeWithBounds(rStart.Add(amount), rEnd.Add(amount), left, right)
+}
+
+//DPCycleFunc是Decimal的周期计算函数，是NPCycleFunc的精确版本。
+type DPCycleFunc struct{}
+
+func (dp *DPCycleFunc) OfCycles(t Decimal, n int, c DecimalCycle) Decimal {
+	return t.Add(c.Unit.MulInt(int64(n) * int64(c.Count)))
+}
+
+//splitDecimalRangeAt函数在有序的分割点处将区间分割为首尾相接的区间，分割点属于后一个区间。
+func splitDecimalRangeAt(dr DecimalRange, points []Decimal) []DecimalRange {
+	start, end := dr.DeRange()
+	left, right := dr.Bounds()
+	result := make([]DecimalRange, 0, len(points)+1)
+	for _, p := range points {
+		result = append(result, CreateDecimalRangeWithBounds(start, p, left, Open))
+		start, left = p, Closed
+	}
+	return append(result, CreateDecimalRangeWithBounds(start, end, left, right))
+}
@@ -0,0 +1,114 @@
+package ranges
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"com.example/common/cycle"
+)
+
+func decimalOf(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestDecimalRange(t *testing.T) {
+	dr := CreateDecimalRange(decimalOf("0"), decimalOf("0.25"))
+	if dr.String() != "[0,0.25)" || !dr.IsIncludedPoint(decimalOf("0.1")) || dr.IsIncludedPoint(decimalOf("1/4")) {
+		t.Errorf("DecimalRange %s", dr.String())
+	}
+	if ok, length := DecimalRangeLength(dr); !ok || length.String() != "0.25" {
+		t.Errorf("DecimalRangeLength(%s) = %v, %s", dr.String(), ok, length)
+	}
+	unbounded := CreateDecimalRangeWithBounds(decimalOf("0"), Decimal{}, Closed, Unbounded)
+	if ok, _ := DecimalRangeLength(unbounded); ok {
+		t.Errorf("the length of %s should be infinite", unbounded.String())
+	}
+	var r DecimalRange
+	if err := json.Unmarshal([]byte(`{"start":"0.1","end":"1/3"}`), &r); err != nil || r.String() != "[0.1,1/3)" {
+		t.Errorf("UnmarshalJSON: got %s, %v", r.String(), err)
+	}
+}
+
+func TestSplitDecimalRange(t *testing.T) {
+	dr := CreateDecimalRangeWithBounds(decimalOf("0"), decimalOf("1"), Closed, Closed)
+	if got := fmt.Sprint(rangesToStrings(SplitDecimalRange(dr, 3))); got != "[[0,1/3) [1/3,2/3) [2/3,1]]" {
+		t.Errorf("SplitDecimalRange: got %s", got)
+	}
+	if got := fmt.Sprint(rangesToStrings(SplitDecimalRangeBy(dr, decimalOf("0.3")))); got != "[[0,0.3) [0.3,0.6) [0.6,0.9) [0.9,1]]" {
+		t.Errorf("SplitDecimalRangeBy: got %s", got)
+	}
+	half := CreateDecimalRange(decimalOf("0"), decimalOf("1"))
+	if got := fmt.Sprint(rangesToStrings(SplitDecimalRangeBy(half, decimalOf("0.5")))); got != "[[0,0.5) [0.5,1)]" {
+		t.Errorf("SplitDecimalRangeBy: got %s", got)
+	}
+	unbounded := CreateDecimalRangeWithBounds(decimalOf("0"), Decimal{}, Closed, Unbounded)
+	if SplitDecimalRange(unbounded, 2) != nil || SplitDecimalRange(dr, 0) != nil || SplitDecimalRangeBy(dr, Decimal{}) != nil {
+		t.Errorf("invalid splits should return nil")
+	}
+}
+
+func TestProrateDecimal(t *testing.T) {
+	month := CreateDecimalRange(decimalOf("0"), decimalOf("30"))
+	ok, fee := ProrateDecimal(decimalOf("100"), month, CreateDecimalRange(decimalOf("0"), decimalOf("10")))
+	if !ok || fee.String() != "100/3" || fee.FloatString(2) != "33.33" {
+		t.Errorf("ProrateDecimal = %v, %s", ok, fee)
+	}
+	//按照天数分摊的三部分之和精确地等于总额
+	total := Decimal{}
+	for _, part := range SplitDecimalRange(month, 3) {
+		_, share := ProrateDecimal(decimalOf("100"), month, part)
+		total = total.Add(share)
+	}
+	if !total.Equal(decimalOf("100")) {
+		t.Errorf("the sum of shares is %s", total)
+	}
+	after := CreateDecimalRangeWithBounds(decimalOf("20"), Decimal{}, Closed, Unbounded)
+	if ok, fee := ProrateDecimal(decimalOf("90"), month, after); !ok || fee.String() != "30" {
+		t.Errorf("ProrateDecimal with an unbounded part = %v, %s", ok, fee)
+	}
+	if ok, _ := ProrateDecimal(decimalOf("90"), after, month); ok {
+		t.Errorf("ProrateDecimal over an unbounded whole should fail")
+	}
+}
+
+func TestDecimalCycle(t *testing.T) {
+	quarter := decimalOf("0.25")
+	origin := CreateDecimalRange(Decimal{}, quarter)
+	calculator := cycle.NewCycleCalculator[DecimalRange, DecimalCycle](origin, DecimalCycle{Count: 1, Unit: quarter}, &DRCycleFunc{})
+	last := origin
+	for i := 1; i < 96; i++ {
+		_, next := calculator.Next()
+		start, _ := next.DeRange()
+		_, end := last.DeRange()
+		if !start.Equal(end) {
+			t.Fatalf("the %dth range %s does not follow %s", i+1, next.String(), last.String())
+		}
+		last = next
+	}
+	if !last.Equal(CreateDecimalRange(decimalOf("23.75"), decimalOf("24"))) {
+		t.Errorf("the 96th range is %s", last.String())
+	}
+
+	//同样的步进用float64会产生误差
+	var f float64
+	for i := 0; i < 96; i++ {
+		f += 0.1
+	}
+	p := (&DPCycleFunc{}).OfCycles(Decimal{}, 96, DecimalCycle{Count: 1, Unit: decimalOf("0.1")})
+	if f == 9.6 || p.String() != "9.6" {
+		t.Errorf("96 steps of 0.1: float %v, decimal %s", f, p)
+	}
+}
+
+func rangesToStrings(rs []DecimalRange) []string {
+	result := make([]string, len(rs))
+	for i, r := range rs {
+		result[i] = r.String()
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package ranges
+
+import "math"
+
+//Gaps函数返回给定范围（bounds）中没有被一组区间（covered）覆盖的部分，结果按照先后顺序排列，互不相交且互不相邻。
+//covered可以是无序的，也可以彼此相交或相邻，超出bounds的部分会被忽略。比如，在[0,10)中，
+//被[1,3)、[2,4)、[6,8)覆盖之后，没有被覆盖的部分是[0,1)、[4,6)与[8,10)。bounds是空区间时，结果为空。
+//Gaps可以用来查找缺失的抄表数据，比如，bounds是一天的时间段，covered是各条读数所覆盖的时间段。
+func Gaps[P any, R any](bounds R, covered []R) []R {
+	return NewRangeSet[P, R](covered...).Complement(bounds).ranges
+}
+
+//CoverageRatio函数计算数字区间bounds被一组区间（covered）覆盖的比例，结果在0到1之间，
+//也就是bounds的长度减去Gaps函数得到的各个空隙的长度之后，与bounds的长度之比。边界类型不影响区间的长度。
+//bounds的长度为0时（比如[a,a]），如果bounds被覆盖，返回1，否则返回0。bounds是空区间时返回1，
+//bounds是无界区间时，比例没有意义，返回NaN。
+func CoverageRatio[P number](bounds NumberRange[P], covered []NumberRange[P]) float64 {
+	if bounds.IsEmpty() {
+		return 1
+	}
+	if left, right := bounds.Bounds(); left == Unbounded || right == Unbounded {
+		return math.NaN()
+	}
+	gaps := Gaps[P, NumberRange[P]](bounds, covered)
+	total := numberLength(bounds)
+	if total == 0 {
+		if len(gaps) == 0 {
+			return 1
+		}
+		return 0
+	}
+	uncovered := 0.0
+	for _, gap := range gaps {
+		uncovered += numberLength(gap)
+	}
+	return (total - uncovered) / total
+}
+
+//numberLength函数返回有界数字区间的长度，也就是终点与起点之差。
+func numberLength[P number](nr NumberRange[P]) float64 {
+	start, end := nr.DeRange()
+	return float64(end) - float64(start)
+}
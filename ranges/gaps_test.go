@@ -0,0 +1,64 @@
+package ranges
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGaps(t *testing.T) {
+	covered := []NumberRange[int]{CreateNumberRange(6, 8), CreateNumberRange(1, 3), CreateNumberRange(2, 4), CreateNumberRange(12, 15)}
+	gaps := Gaps[int, NumberRange[int]](CreateNumberRange(0, 10), covered)
+	if s := NewRangeSet[int, NumberRange[int]](gaps...).String(); len(gaps) != 3 || s != "{[0,1),[4,6),[8,10)}" {
+		t.Errorf("Gaps: got %s", s)
+	}
+	if gaps := Gaps[int, NumberRange[int]](CreateNumberRange(1, 4), covered); len(gaps) != 0 {
+		t.Errorf("Gaps: got %v", gaps)
+	}
+	if gaps := Gaps[int, NumberRange[int]](CreateNumberRange(3, 3), covered); len(gaps) != 0 {
+		t.Errorf("Gaps of an empty range: got %v", gaps)
+	}
+	closed := CreateNumberRangeWithBounds(0, 4, Closed, Closed)
+	if gaps := Gaps[int, NumberRange[int]](closed, covered); len(gaps) != 2 || gaps[0].String() != "[0,1)" || gaps[1].String() != "[4,5)" {
+		t.Errorf("Gaps of %s: got %v", closed.String(), gaps)
+	}
+
+	//一天中每小时一条读数，缺少3点与10点到12点的读数
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var readings []TimeInterval
+	for h := 0; h < 24; h++ {
+		if h != 3 && (h < 10 || h >= 12) {
+			readings = append(readings, CreateTimeInterval(day.Add(time.Duration(h)*time.Hour), day.Add(time.Duration(h+1)*time.Hour)))
+		}
+	}
+	missing := Gaps[time.Time, TimeInterval](CreateTimeInterval(day, day.Add(24*time.Hour)), readings)
+	if len(missing) != 2 || Tintvl2Str(missing[0]) != "[2022-01-01 03:00:00,2022-01-01 04:00:00)" ||
+		Tintvl2Str(missing[1]) != "[2022-01-01 10:00:00,2022-01-01 12:00:00)" {
+		t.Errorf("Gaps: got %v", missing)
+	}
+}
+
+func TestCoverageRatio(t *testing.T) {
+	covered := []NumberRange[float64]{CreateNumberRange(6.0, 8.0), CreateNumberRange(1.0, 3.0), CreateNumberRange(2.0, 4.0)}
+	for _, c := range []struct {
+		bounds NumberRange[float64]
+		want   float64
+	}{
+		{CreateNumberRange(0.0, 10.0), 0.5},
+		{CreateNumberRange(1.0, 4.0), 1},
+		{CreateNumberRange(8.0, 10.0), 0},
+		{CreateNumberRange(3.0, 7.0), 0.5},
+		{CreateNumberRangeWithBounds(2.0, 2.0, Closed, Closed), 1},
+		{CreateNumberRangeWithBounds(5.0, 5.0, Closed, Closed), 0},
+	} {
+		if got := CoverageRatio(c.bounds, covered); got != c.want {
+			t.Errorf("CoverageRatio(%s) = %v, want %v", c.bounds.String(), got, c.want)
+		}
+	}
+	if got := CoverageRatio(CreateNumberRangeFrom(0.0, Closed), covered); !math.IsNaN(got) {
+		t.Errorf("CoverageRatio of an unbounded range = %v", got)
+	}
+	if got := CoverageRatio(CreateNumberRange(0, 4), []NumberRange[int]{CreateNumberRange(1, 2)}); got != 0.25 {
+		t.Errorf("CoverageRatio = %v", got)
+	}
+}
@@ -0,0 +1,266 @@
+package ranges
+
+//IntervalEntry[R,V]是区间树中的一个条目，由区间及其所携带的数据（payload）构成。
+type IntervalEntry[R, V any] struct {
+	Range R
+	Value V
+}
+
+//IntervalTree[P,R,V]定义了适用于任何Range[P,R]区间的增强区间树（augmented interval tree）。
+//区间树是以区间起点（起点相同时以终点）为键的平衡二叉查找树（AVL树），
+//每个节点还记录了以该节点为根的子树中终点最晚的区间，查询时据此剪掉不可能有结果的子树。
+//因而，插入与删除的时间复杂度为O(log n)，点查询（Stab）与相交查询（Overlap）的时间复杂度
+//约为O(log n + k)，其中k是查询结果的个数。
+//区间树中可以存在多个相等的区间，每个区间可以携带一个类型为V的数据。
+//IntervalTree不是线程安全类型，请注意不要在多线程环境下使用。
+type IntervalTree[P any, R any, V any] struct {
+	root *itNode[P, R, V]
+	size int
+}
+
+//itNode是区间树的节点
+type itNode[P any, R any, V any] struct {
+	entry       IntervalEntry[R, V]
+	maxEnd      Range[P, R] //子树中终点最晚的区间
+	left, right *itNode[P, R, V]
+	height      int
+}
+
+//NewIntervalTree函数构造一个空的区间树，并返回其指针。
+func NewIntervalTree[P any, R any, V any]() *IntervalTree[P, R, V] {
+	return &IntervalTree[P, R, V]{}
+}
+
+//Len方法返回区间树中条目的个数。
+func (t *IntervalTree[P, R, V]) Len() int {
+	return t.size
+}
+
+//Insert方法将区间r及其携带的数据v插入区间树。
+func (t *IntervalTree[P, R, V]) Insert(r R, v V) {
+	t.root = t.root.insert(IntervalEntry[R, V]{Range: r, Value: v})
+	t.size++
+}
+
+//Delete方法从区间树中删除一个与区间r相等的条目，返回被删除条目携带的数据。
+//如果区间树中不存在与r相等的区间，返回false。如果存在多个与r相等的区间，只删除其中的一个。
+func (t *IntervalTree[P, R, V]) Delete(r R) (V, bool) {
+	var deleted *itNode[P, R, V]
+	t.root = t.root.delete(typeTo[R, Range[P, R]](r), &deleted)
+	if deleted == nil {
+		var zero V
+		return zero, false
+	}
+	t.size--
+	return deleted.entry.Value, true
+}
+
+//Stab方法返回区间树中所有包含点p的条目，也就是IsIncludedPoint(p)为true的区间，结果按照区间起点排序。
+func (t *IntervalTree[P, R, V]) Stab(p P) []IntervalEntry[R, V] {
+	var result []IntervalEntry[R, V]
+	t.root.stab(p, func(e IntervalEntry[R, V]) bool {
+		result = append(result, e)
+		return true
+	})
+	return result
+}
+
+//VisitStab方法按照区间起点的顺序，依次使用函数f访问所有包含点p的条目，f返回false时停止访问。
+func (t *IntervalTree[P, R, V]) VisitStab(p P, f func(e IntervalEntry[R, V]) bool) {
+	t.root.stab(p, f)
+}
+
+//Overlap方法返回区间树中所有与区间r相交的条目，结果按照区间起点排序。
+func (t *IntervalTree[P, R, V]) Overlap(r R) []IntervalEntry[R, V] {
+	var result []IntervalEntry[R, V]
+	t.root.overlap(typeTo[R, Range[P, R]](r), func(e IntervalEntry[R, V]) bool {
+		result = append(result, e)
+		return true
+	})
+	return result
+}
+
+//VisitOverlap方法按照区间起点的顺序，依次使用函数f访问所有与区间r相交的条目，f返回false时停止访问。
+func (t *IntervalTree[P, R, V]) VisitOverlap(r R, f func(e IntervalEntry[R, V]) bool) {
+	t.root.overlap(typeTo[R, Range[P, R]](r), f)
+}
+
+//Walk方法按照区间起点的顺序，依次使用函数f访问区间树中的所有条目，f返回false时停止访问。
+func (t *IntervalTree[P, R, V]) Walk(f func(e IntervalEntry[R, V]) bool) {
+	t.root.walk(f)
+}
+
+/////////////////////////////下面是区间树节点的内部方法////////////////////////////////
+
+//compareRanges函数按照起点、终点的顺序比较区间a与b，a在前返回-1，b在前返回1，相等返回0。
+func compareRanges[P any, R any](a, b Range[P, R]) int {
+	switch {
+	case startsBefore(a, b):
+		return -1
+	case startsBefore(b, a):
+		return 1
+	}
+	aEndsFirst, bEndsFirst := endsNotAfter(a, b), endsNotAfter(b, a)
+	switch {
+	case aEndsFirst && bEndsFirst:
+		return 0
+	case aEndsFirst:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func (n *itNode[P, R, V]) rng() Range[P, R] {
+	return typeTo[R, Range[P, R]](n.entry.Range)
+}
+
+func (n *itNode[P, R, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+//update方法在子树结构发生变化后，重新计算节点的高度与子树中终点最晚的区间。
+func (n *itNode[P, R, V]) update() {
+	lh, rh := n.left.getHeight(), n.right.getHeight()
+	n.height = lh + 1
+	if rh > lh {
+		n.height = rh + 1
+	}
+	n.maxEnd = n.rng()
+	for _, child := range []*itNode[P, R, V]{n.left, n.right} {
+		if child != nil && !endsNotAfter(child.maxEnd, n.maxEnd) {
+			n.maxEnd = child.maxEnd
+		}
+	}
+}
+
+func (n *itNode[P, R, V]) rotateLeft() *itNode[P, R, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *itNode[P, R, V]) rotateRight() *itNode[P, R, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+//rebalance方法在插入或删除之后恢复AVL树的平衡，返回新的子树根节点。
+func (n *itNode[P, R, V]) rebalance() *itNode[P, R, V] {
+	n.update()
+	balance := n.left.getHeight() - n.right.getHeight()
+	if balance > 1 {
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	}
+	if balance < -1 {
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *itNode[P, R, V]) insert(e IntervalEntry[R, V]) *itNode[P, R, V] {
+	if n == nil {
+		node := &itNode[P, R, V]{entry: e}
+		node.update()
+		return node
+	}
+	if compareRanges(typeTo[R, Range[P, R]](e.Range), n.rng()) < 0 {
+		n.left = n.left.insert(e)
+	} else {
+		n.right = n.right.insert(e)
+	}
+	return n.rebalance()
+}
+
+//delete方法在子树中删除一个与区间r相等的节点，被删除的节点通过deleted返回。
+func (n *itNode[P, R, V]) delete(r Range[P, R], deleted **itNode[P, R, V]) *itNode[P, R, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := compareRanges(r, n.rng()); {
+	case c < 0:
+		n.left = n.left.delete(r, deleted)
+	case c > 0:
+		n.right = n.right.delete(r, deleted)
+	default:
+		*deleted = n
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		//用右子树中最小的节点替代被删除的节点
+		var min *itNode[P, R, V]
+		right := n.right.deleteMin(&min)
+		min.left, min.right = n.left, right
+		return min.rebalance()
+	}
+	return n.rebalance()
+}
+
+func (n *itNode[P, R, V]) deleteMin(min **itNode[P, R, V]) *itNode[P, R, V] {
+	if n.left == nil {
+		*min = n
+		return n.right
+	}
+	n.left = n.left.deleteMin(min)
+	return n.rebalance()
+}
+
+func (n *itNode[P, R, V]) stab(p P, f func(e IntervalEntry[R, V]) bool) bool {
+	//子树中终点最晚的区间都在点p之前，则子树中没有包含p的区间
+	if n == nil || n.maxEnd.IsBeforePoint(p) {
+		return true
+	}
+	if !n.left.stab(p, f) {
+		return false
+	}
+	//节点区间的起点在点p之后，则右子树中区间的起点也都在点p之后
+	if n.rng().IsAfterPoint(p) {
+		return true
+	}
+	if n.rng().IsIncludedPoint(p) && !f(n.entry) {
+		return false
+	}
+	return n.right.stab(p, f)
+}
+
+func (n *itNode[P, R, V]) overlap(r Range[P, R], f func(e IntervalEntry[R, V]) bool) bool {
+	if n == nil || IsBefore(n.maxEnd, r) {
+		return true
+	}
+	if !n.left.overlap(r, f) {
+		return false
+	}
+	if IsAfter(n.rng(), r) {
+		return true
+	}
+	if IsIntersected(n.rng(), r) && !f(n.entry) {
+		return false
+	}
+	return n.right.overlap(r, f)
+}
+
+func (n *itNode[P, R, V]) walk(f func(e IntervalEntry[R, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.walk(f) && f(n.entry) && n.right.walk(f)
+}
@@ -0,0 +1,69 @@
+package ranges
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIntervalTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int, NumberRange[int], int]()
+	var all []NumberRange[int]
+	for i := 0; i < 500; i++ {
+		start := rnd.Intn(1000)
+		r := CreateNumberRange(start, start+1+rnd.Intn(50))
+		tree.Insert(r, i)
+		all = append(all, r)
+	}
+	//删除一部分区间，包括不存在的区间
+	for i := 0; i < 200; i++ {
+		if _, ok := tree.Delete(all[i]); !ok {
+			t.Fatalf("Delete(%s) failed", all[i].String())
+		}
+	}
+	all = all[200:]
+	if _, ok := tree.Delete(CreateNumberRange(-5, -1)); ok {
+		t.Errorf("Delete of a missing range succeeded")
+	}
+	if tree.Len() != len(all) {
+		t.Errorf("Len() = %d, want %d", tree.Len(), len(all))
+	}
+	for p := -1; p < 1060; p += 7 {
+		want := 0
+		for _, r := range all {
+			if r.IsIncludedPoint(p) {
+				want++
+			}
+		}
+		got := tree.Stab(p)
+		if len(got) != want {
+			t.Errorf("Stab(%d) returned %d entries, want %d", p, len(got), want)
+		}
+		for _, e := range got {
+			if !e.Range.IsIncludedPoint(p) {
+				t.Errorf("Stab(%d) returned %s", p, e.Range.String())
+			}
+		}
+	}
+	for q := 0; q < 1050; q += 13 {
+		query := CreateNumberRange(q, q+5)
+		want := 0
+		for _, r := range all {
+			if r.IsIntersected(query) {
+				want++
+			}
+		}
+		if got := tree.Overlap(query); len(got) != want {
+			t.Errorf("Overlap(%s) returned %d entries, want %d", query.String(), len(got), want)
+		}
+	}
+	var previous *NumberRange[int]
+	tree.Walk(func(e IntervalEntry[NumberRange[int], int]) bool {
+		if previous != nil && startsBefore[int, NumberRange[int]](e.Range, *previous) {
+			t.Errorf("Walk is out of order: %s after %s", e.Range.String(), previous.String())
+		}
+		r := e.Range
+		previous = &r
+		return true
+	})
+}
@@ -0,0 +1,226 @@
+package ranges
+
+import "math"
+
+//integer约束了各种整数类型。
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+//CreateIntRange函数用于给定的两个整数创建一个左闭右开区间，
+//无论两个整数的大小顺序如何，创建出来的区间的起点都会小于终点。
+func CreateIntRange[P integer](p1, p2 P) IntRange[P] {
+	return CreateIntRangeWithBounds(p1, p2, Closed, Open)
+}
+
+//CreateIntRangeWithBounds函数用于给定的两个整数及其边界类型创建一个区间，并将其化为规范形式，
+//left是p1的边界类型，right是p2的边界类型。如果p1大于p2，两个整数连同其边界类型一起交换。
+//比如，[1,3]、(0,3]与(0,4)的规范形式都是[1,4)。
+func CreateIntRangeWithBounds[P integer](p1, p2 P, left, right BoundType) IntRange[P] {
+	if left != Unbounded && right != Unbounded && p1 > p2 {
+		p1, p2, left, right = p2, p1, right, left
+	}
+	return canonicalIntRange(p1, p2, left, right)
+}
+
+//CreateIntRangeFrom函数创建一个以start为起点、终点无界的区间，比如，[start,+∞)，
+//left是起点的边界类型。
+func CreateIntRangeFrom[P integer](start P, left BoundType) IntRange[P] {
+	return CreateIntRangeWithBounds(start, start, left, Unbounded)
+}
+
+//CreateIntRangeTo函数创建一个起点无界、以end为终点的区间，比如，(-∞,end)，
+//right是终点的边界类型。
+func CreateIntRangeTo[P integer](end P, right BoundType) IntRange[P] {
+	return CreateIntRangeWithBounds(end, end, Unbounded, right)
+}
+
+//CreateUnboundedIntRange函数创建一个包含类型P所有整数的区间。
+func CreateUnboundedIntRange[P integer]() IntRange[P] {
+	var zero P
+	return CreateIntRangeWithBounds(zero, zero, Unbounded, Unbounded)
+}
+
+//IntRangeOf函数将整数类型的数字区间nr转换为规范形式的整数区间，二者包含相同的整数。
+func IntRangeOf[P integer](nr NumberRange[P]) IntRange[P] {
+	start, end := nr.DeRange()
+	left, right := nr.Bounds()
+	return CreateIntRangeWithBounds(start, end, left, right)
+}
+
+//IntRange[P integer]定义了离散的整数区间类型，该类型的区间满足Range[P, IntRange[P]]接口。
+//与NumberRange不同，IntRange总是保持规范形式：起点是闭边界，终点是开边界，
+//类型P的最小值被用作无界的起点，而包含类型P最大值的区间，其终点是无界的，比如，[1,3]与(0,3]都被化为[1,4)，
+//(-∞,5)被化为[-128,5)（P为int8时），[250,255]被化为[250,+∞)（P为uint8时）。
+//因而，包含相同整数的区间一定相等，[1,2]与[3,4]这样在整数上相邻的区间也可以被合并为[1,5)。
+//零值的IntRange是空区间[0,0)。
+type IntRange[P integer] struct {
+	start  P
+	end    P
+	bounds boundPair //起点与终点的边界类型，起点总是闭边界，终点是开边界或者无界
+}
+
+func (ir IntRange[P]) Range(start, end P) IntRange[P] {
+	return CreateIntRange(start, end)
+}
+
+func (ir IntRange[P]) RangeWithBounds(start, end P, left, right BoundType) IntRange[P] {
+	return CreateIntRangeWithBounds(start, end, left, right)
+}
+
+func (ir IntRange[P]) DeRange() (start, end P) {
+	return ir.start, ir.end
+}
+
+func (ir IntRange[P]) Bounds() (left, right BoundType) {
+	return ir.bounds.types()
+}
+
+func (ir IntRange[P]) IsIncludedPoint(p P) bool {
+	return p >= ir.start && (ir.bounds.rightUnbounded || p < ir.end)
+}
+func (ir IntRange[P]) IsBeforePoint(p P) bool {
+	return !ir.bounds.rightUnbounded && p >= ir.end
+}
+func (ir IntRange[P]) IsAfterPoint(p P) bool {
+	return p < ir.start
+}
+
+//Count方法返回区间中整数的个数。对于包含64位整数类型所有整数的区间，个数2^64超出了uint64的范围，返回math.MaxUint64。
+func (ir IntRange[P]) Count() uint64 {
+	if ir.IsEmpty() {
+		return 0
+	}
+	if !ir.bounds.rightUnbounded {
+		//补码减法，即使二者之差超出了类型P的范围，结果仍然正确
+		return uint64(ir.end) - uint64(ir.start)
+	}
+	_, max := integerLimits[P]()
+	n := uint64(max) - uint64(ir.start)
+	if n == math.MaxUint64 {
+		return n
+	}
+	return n + 1
+}
+
+//Walk方法从小到大依次使用函数f访问区间中的整数，f返回false时停止访问。
+func (ir IntRange[P]) Walk(f func(p P) bool) {
+	if ir.IsEmpty() {
+		return
+	}
+	_, max := integerLimits[P]()
+	for p := ir.start; ir.IsIncludedPoint(p); p++ {
+		if !f(p) || p == max {
+			return
+		}
+	}
+}
+
+//NumberRange方法将整数区间转换为包含相同整数的数字区间。
+func (ir IntRange[P]) NumberRange() NumberRange[P] {
+	left, right := ir.Bounds()
+	return CreateNumberRangeWithBounds(ir.start, ir.end, left, right)
+}
+
+////////////////////////////////////////////////////////////////////
+func (ir IntRange[P]) IsEmpty() bool {
+	return IsEmpty[P, IntRange[P]](ir)
+}
+
+//IsPoint方法判断区间是否只包含一个整数，比如[3,4)。
+func (ir IntRange[P]) IsPoint() bool {
+	return ir.Count() == 1
+}
+func (ir IntRange[P]) String() string {
+	return RngToStr[P, IntRange[P]](ir, v2s[P])
+}
+func (ir IntRange[P]) Equal(other IntRange[P]) bool {
+	return Equal[P, IntRange[P]](ir, other)
+}
+func (ir IntRange[P]) IsIntersected(other IntRange[P]) bool {
+	return IsIntersected[P, IntRange[P]](ir, other)
+}
+func (ir IntRange[P]) Intersect(other IntRange[P]) (bool, IntRange[P]) {
+	return Intersect[P, IntRange[P]](ir, other)
+}
+func (ir IntRange[P]) IntersectOthers(others []IntRange[P]) (bool, IntRange[P]) {
+	return IntersectOthers[P, IntRange[P]](ir, others)
+}
+func (ir IntRange[P]) Union(other IntRange[P]) (bool, IntRange[P]) {
+	return Union[P, IntRange[P]](ir, other)
+}
+func (ir IntRange[P]) UnionOthers(others []IntRange[P]) (bool, IntRange[P]) {
+	return UnionOthers[P, IntRange[P]](ir, others)
+}
+func (ir IntRange[P]) Except(other IntRange[P]) (r1, r2 IntRange[P]) {
+	return Except[P, IntRange[P]](ir, other)
+}
+func (ir IntRange[P]) ExceptOthers(others []IntRange[P]) []IntRange[P] {
+	return ExceptOthers[P, IntRange[P]](ir, others)
+}
+func (ir IntRange[P]) IsBefore(other IntRange[P]) bool {
+	return IsBefore[P, IntRange[P]](ir, other)
+}
+func (ir IntRange[P]) IsAfter(other IntRange[P]) bool {
+	return IsAfter[P, IntRange[P]](ir, other)
+}
+
+//MarshalJSON方法将区间编码为JSON对象，比如，{"start":1,"end":5}，编码规则与NumberRange相同。
+func (ir IntRange[P]) MarshalJSON() ([]byte, error) {
+	return marshalRangeJSON[P, IntRange[P]](ir)
+}
+
+//UnmarshalJSON方法将JSON数据解码为区间，并化为规范形式，解码规则与NumberRange相同。JSON数据为null时，区间保持不变。
+func (ir *IntRange[P]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	result, err := unmarshalRangeJSON[P, IntRange[P]](*ir, data)
+	if err != nil {
+		return err
+	}
+	*ir = result
+	return nil
+}
+
+//canonicalIntRange函数将起点不大于终点的整数区间化为规范形式。
+func canonicalIntRange[P integer](start, end P, left, right BoundType) IntRange[P] {
+	min, max := integerLimits[P]()
+	switch left {
+	case Unbounded:
+		start = min
+	case Open:
+		if start == max {
+			return IntRange[P]{start: max, end: max}
+		}
+		start++
+	}
+	switch right {
+	case Unbounded:
+		return IntRange[P]{start: start, bounds: makeBoundPair(Closed, Unbounded)}
+	case Closed:
+		if end == max {
+			return IntRange[P]{start: start, bounds: makeBoundPair(Closed, Unbounded)}
+		}
+		end++
+	}
+	if end < start {
+		end = start
+	}
+	return IntRange[P]{start: start, end: end}
+}
+
+//integerLimits函数返回整数类型P的最小值与最大值，P必须是整数类型，参见canonicalIntNumberRange。
+func integerLimits[P number]() (min, max P) {
+	var zero P
+	if zero-1 > zero {
+		return zero, zero - 1
+	}
+	p := P(1)
+	for p*2 > p {
+		p *= 2
+	}
+	max = p + (p - 1)
+	return -max - 1, max
+}
@@ -0,0 +1,129 @@
+package ranges
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestIntRangeCanonical(t *testing.T) {
+	ir := CreateIntRangeWithBounds[int]
+	want := CreateIntRange(1, 4)
+	for _, r := range []IntRange[int]{ir(1, 3, Closed, Closed), ir(0, 3, Open, Closed), ir(0, 4, Open, Open), ir(3, 1, Closed, Closed), IntRangeOf(CreateNumberRangeWithBounds(0, 3, Open, Closed))} {
+		if !r.Equal(want) || r.String() != "[1,4)" {
+			t.Errorf("%s should equal %s", r.String(), want.String())
+		}
+	}
+	if s := CreateIntRangeTo(int8(5), Open).String(); s != "[-128,5)" {
+		t.Errorf("String: got %s", s)
+	}
+	if s := CreateIntRangeWithBounds(uint8(250), 255, Closed, Closed).String(); s != "[250,+∞)" {
+		t.Errorf("String: got %s", s)
+	}
+	if !ir(3, 4, Open, Open).IsEmpty() || !ir(3, 3, Closed, Closed).IsPoint() || !CreateIntRangeFrom(int8(127), Open).IsEmpty() {
+		t.Errorf("empty and point ranges: wrong result")
+	}
+	if ok, u := ir(1, 2, Closed, Closed).Union(ir(3, 4, Closed, Closed)); !ok || u.String() != "[1,5)" {
+		t.Errorf("Union: got %s, %v", u.String(), ok)
+	}
+	if ok, u := ir(1, 2, Closed, Closed).Union(ir(4, 5, Closed, Closed)); ok || u.String() != "[1,6)" {
+		t.Errorf("Union: got %s, %v", u.String(), ok)
+	}
+	r1, r2 := ir(1, 10, Closed, Closed).Except(ir(3, 5, Closed, Closed))
+	if r1.String() != "[1,3)" || r2.String() != "[6,11)" {
+		t.Errorf("Except: got %s and %s", r1.String(), r2.String())
+	}
+	set := NewRangeSet[int, IntRange[int]](ir(1, 2, Closed, Closed), ir(5, 6, Closed, Closed), ir(3, 4, Closed, Closed))
+	if set.String() != "{[1,7)}" {
+		t.Errorf("RangeSet: got %s", set.String())
+	}
+	var decoded IntRange[int]
+	if err := json.Unmarshal([]byte(`"[1,3]"`), &decoded); err != nil || !decoded.Equal(want) {
+		t.Errorf("UnmarshalJSON: got %s, %v", decoded.String(), err)
+	}
+	if nr := want.NumberRange(); nr.String() != "[1,4)" {
+		t.Errorf("NumberRange: got %s", nr.String())
+	}
+}
+
+func TestIntegerNumberRange(t *testing.T) {
+	//整数类型的NumberRange被化为规范形式，包含相同整数的区间相等，整数上相邻的区间可以合并
+	nr := CreateNumberRangeWithBounds[int]
+	closed, halfOpen, open := nr(1, 3, Closed, Closed), CreateNumberRange(1, 4), nr(0, 3, Open, Closed)
+	if !closed.Equal(halfOpen) || !open.Equal(halfOpen) || closed.String() != "[1,4)" || !nr(0, 4, Open, Open).Equal(closed) {
+		t.Errorf("%s, %s and %s should be equal", closed.String(), halfOpen.String(), open.String())
+	}
+	if !IntRangeOf(closed).Equal(IntRangeOf(halfOpen)) || IntRangeOf(closed).Count() != 3 || !IntRangeOf(closed).NumberRange().Equal(closed) {
+		t.Errorf("IntRangeOf(%s) should equal IntRangeOf(%s)", closed.String(), halfOpen.String())
+	}
+	a, b := nr(1, 2, Closed, Closed), nr(3, 4, Closed, Closed)
+	if ok, u := a.Union(b); !ok || u.String() != "[1,5)" {
+		t.Errorf("%s+%s: got %s, %v", a.String(), b.String(), u.String(), ok)
+	}
+	if set := NewRangeSet[int, NumberRange[int]](a, b, nr(5, 6, Closed, Closed)); set.String() != "{[1,7)}" {
+		t.Errorf("RangeSet: got %s", set.String())
+	}
+	if !nr(3, 3, Closed, Closed).IsPoint() || !nr(3, 5, Open, Open).IsPoint() || !nr(3, 4, Open, Open).IsEmpty() || nr(3, 5, Closed, Open).IsPoint() {
+		t.Errorf("IsPoint/IsEmpty of integer ranges: wrong result")
+	}
+	//类型的最大值没有后继，作为终点时保持闭边界
+	top := CreateNumberRangeWithBounds[uint8](250, 255, Closed, Closed)
+	if top.String() != "[250,255]" || !top.IsIncludedPoint(255) || !top.Equal(CreateNumberRangeWithBounds[uint8](249, 255, Open, Closed)) {
+		t.Errorf("range ending at the maximum: got %s", top.String())
+	}
+	if !CreateNumberRangeWithBounds[uint8](255, 255, Open, Closed).IsEmpty() || !CreateNumberRangeWithBounds[int8](127, 127, Closed, Closed).IsPoint() {
+		t.Errorf("ranges at the maximum: wrong result")
+	}
+	if r, err := ParseNumberRange[int]("(0,3]"); err != nil || !r.Equal(halfOpen) {
+		t.Errorf("ParseNumberRange((0,3]) = %s, %v", r.String(), err)
+	}
+	//浮点数区间不受影响
+	if f := CreateNumberRangeWithBounds(1.0, 3, Closed, Closed); f.String() != "[1,3]" || f.Equal(CreateNumberRange(1.0, 4)) {
+		t.Errorf("float ranges should keep their bounds, got %s", f.String())
+	}
+}
+
+func TestIntRangeCount(t *testing.T) {
+	cases := []struct {
+		count, want uint64
+	}{
+		{CreateIntRangeWithBounds(1, 3, Closed, Closed).Count(), 3},
+		{CreateIntRange(5, 5).Count(), 0},
+		{CreateIntRange(int8(-128), int8(127)).Count(), 255},
+		{CreateUnboundedIntRange[int8]().Count(), 256},
+		{CreateUnboundedIntRange[uint16]().Count(), 65536},
+		{CreateIntRangeFrom(int64(0), Closed).Count(), 1 << 63},
+		{CreateIntRange(int64(math.MinInt64), math.MaxInt64).Count(), math.MaxUint64},
+		{CreateUnboundedIntRange[int64]().Count(), math.MaxUint64},
+	}
+	for i, c := range cases {
+		if c.count != c.want {
+			t.Errorf("case %d: Count = %d, want %d", i, c.count, c.want)
+		}
+	}
+	var pages []int
+	CreateIntRangeWithBounds(1, 5, Closed, Closed).Walk(func(p int) bool {
+		pages = append(pages, p)
+		return true
+	})
+	if len(pages) != 5 || pages[0] != 1 || pages[4] != 5 {
+		t.Errorf("Walk: got %v", pages)
+	}
+	var last uint8
+	n := 0
+	CreateIntRangeFrom(uint8(250), Closed).Walk(func(p uint8) bool {
+		last, n = p, n+1
+		return true
+	})
+	if n != 6 || last != 255 {
+		t.Errorf("Walk should stop at the maximum, got %d values ending at %d", n, last)
+	}
+	n = 0
+	CreateIntRange(0, 100).Walk(func(p int) bool {
+		n++
+		return p < 9
+	})
+	if n != 10 {
+		t.Errorf("Walk should stop when f returns false, got %d values", n)
+	}
+}
@@ -0,0 +1,419 @@
+package ranges
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+本文件实现了ISO 8601时间间隔（time interval）与重复时间间隔（recurring time interval）的解析与格式化。
+ISO 8601时间间隔有如下四种形式：
+    start/end        比如，2022-01-01T00:00Z/2022-01-01T00:15Z
+    start/duration   比如，2022-01-01T00:00Z/PT15M
+    duration/end     比如，PT15M/2022-01-01T00:15Z
+    duration         比如，PT15M，只有时长，没有起止时间
+重复时间间隔是在时间间隔之前加上重复次数，比如，R96/2022-01-01T00:00Z/PT15M表示从2022-01-01T00:00Z开始，
+连续96个15分钟的时间段，省略重复次数（R/...）表示无限重复。
+重复时间间隔可以转换为一个时间段（TimeInterval）与一个时间周期（TimeCycle），直接用于cycle.NewCycleCalculator：
+    origin, tc, err := ri.Cycle()
+    calculator := cycle.NewCycleCalculator[ranges.TimeInterval, ranges.TimeCycle](origin, tc, &ranges.TICycleFunc{})
+**/
+
+//ISO_TIME_LAYOUT是格式化ISO 8601时间间隔时使用的时间格式。
+const ISO_TIME_LAYOUT = time.RFC3339Nano
+
+//isoTimeLayouts是解析ISO 8601时间间隔时，时间点可以使用的格式，包括扩展格式与基本格式，
+//以及省略秒或者省略时分秒的格式。没有时区的时间点被视为UTC时间。
+var isoTimeLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"20060102T150405Z0700",
+	"20060102T1504Z0700",
+	"20060102T150405",
+	"20060102T1504",
+	"20060102",
+}
+
+//ISODuration定义了ISO 8601中的时长，比如，P1Y2M10DT2H30M表示1年2个月10天2小时30分钟。
+//年、月、周、天是日历上的时长，其实际长度取决于起始的时间点，而时、分、秒则是固定的时长，记录在Clock中。
+type ISODuration struct {
+	Years  int
+	Months int
+	Weeks  int
+	Days   int
+	Clock  time.Duration //时长中T之后的时、分、秒部分
+}
+
+//ISODurationOf函数将固定的时长d转换为ISO 8601时长，比如，15分钟转换为PT15M。
+func ISODurationOf(d time.Duration) ISODuration {
+	return ISODuration{Clock: d}
+}
+
+//ParseISODuration函数解析ISO 8601时长，比如，“PT15M”、“P1DT12H”、“P2W”、“PT0.5S”。
+//时长的最后一个部分可以带有小数，小数点可以是“.”或者“,”。
+//时长之前可以带有符号，比如，“-PT1H”表示负的1小时，各部分的符号不同时，部分的数值之前也可以带有负号，比如，“P1DT-1H”，参见String方法。
+func ParseISODuration(s string) (ISODuration, error) {
+	var d ISODuration
+	body := strings.TrimPrefix(strings.TrimPrefix(s, "+"), "-")
+	if len(body) < 2 || body[0] != 'P' {
+		return d, &ParseError{Text: s, Msg: "duration must start with 'P'"}
+	}
+	rest := body[1:]
+	inClock := false
+	hasField := false
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inClock {
+				return d, &ParseError{Text: s, Msg: "duplicate 'T' in duration"}
+			}
+			inClock = true
+			rest = rest[1:]
+			continue
+		}
+		i := 0
+		if rest[0] == '-' {
+			i++
+		}
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.' || rest[i] == ',') {
+			i++
+		}
+		if i == 0 || rest[i-1] == '-' || i == len(rest) {
+			return d, &ParseError{Text: s, Msg: "invalid duration field " + strconv.Quote(rest)}
+		}
+		value, err := strconv.ParseFloat(strings.Replace(rest[:i], ",", ".", 1), 64)
+		if err != nil {
+			return d, &ParseError{Text: s, Msg: "invalid duration value", Err: err}
+		}
+		designator := rest[i]
+		rest = rest[i+1:]
+		isFraction := value != float64(int(value))
+		if isFraction && rest != "" {
+			return d, &ParseError{Text: s, Msg: "only the last duration field may have a fraction"}
+		}
+		if !inClock && isFraction {
+			return d, &ParseError{Text: s, Msg: "date fields of a duration must be integers"}
+		}
+		hasField = true
+		switch {
+		case !inClock && designator == 'Y':
+			d.Years += int(value)
+		case !inClock && designator == 'M':
+			d.Months += int(value)
+		case !inClock && designator == 'W':
+			d.Weeks += int(value)
+		case !inClock && designator == 'D':
+			d.Days += int(value)
+		case inClock && designator == 'H':
+			d.Clock += time.Duration(value * float64(time.Hour))
+		case inClock && designator == 'M':
+			d.Clock += time.Duration(value * float64(time.Minute))
+		case inClock && designator == 'S':
+			d.Clock += time.Duration(value * float64(time.Second))
+		default:
+			return d, &ParseError{Text: s, Msg: "invalid duration designator " + strconv.Quote(string(designator))}
+		}
+	}
+	if !hasField {
+		return d, &ParseError{Text: s, Msg: "duration has no fields"}
+	}
+	if s[0] == '-' {
+		d = d.neg()
+	}
+	return d, nil
+}
+
+//String方法将时长格式化为ISO 8601形式，比如，PT15M、P1DT2H，零时长格式化为PT0S。
+//负的时长在P之前带有负号，比如，-PT1H30M。ISO 8601无法表示各部分符号不同的时长，
+//此时各个负的部分分别带有负号，比如，P1DT-1H-30M，ParseISODuration可以解析这种形式。
+func (d ISODuration) String() string {
+	if d.isNegative() {
+		return "-" + d.neg().String()
+	}
+	var sb strings.Builder
+	sb.WriteByte('P')
+	for _, field := range []struct {
+		value      int
+		designator byte
+	}{{d.Years, 'Y'}, {d.Months, 'M'}, {d.Weeks, 'W'}, {d.Days, 'D'}} {
+		if field.value != 0 {
+			sb.WriteString(strconv.Itoa(field.value))
+			sb.WriteByte(field.designator)
+		}
+	}
+	if d.Clock == 0 {
+		if sb.Len() == 1 {
+			sb.WriteString("T0S")
+		}
+		return sb.String()
+	}
+	sb.WriteByte('T')
+	clock, sign := d.Clock, ""
+	if clock < 0 {
+		clock, sign = -clock, "-"
+	}
+	if h := clock / time.Hour; h > 0 {
+		sb.WriteString(sign + strconv.FormatInt(int64(h), 10) + "H")
+		clock -= h * time.Hour
+	}
+	if m := clock / time.Minute; m > 0 {
+		sb.WriteString(sign + strconv.FormatInt(int64(m), 10) + "M")
+		clock -= m * time.Minute
+	}
+	if clock > 0 {
+		sb.WriteString(sign + strconv.FormatFloat(clock.Seconds(), 'f', -1, 64) + "S")
+	}
+	return sb.String()
+}
+
+//isNegative方法判断时长的各个部分是否都不大于0，并且至少有一个部分小于0。
+func (d ISODuration) isNegative() bool {
+	fields := []int64{int64(d.Years), int64(d.Months), int64(d.Weeks), int64(d.Days), int64(d.Clock)}
+	negative := false
+	for _, f := range fields {
+		if f > 0 {
+			return false
+		}
+		negative = negative || f < 0
+	}
+	return negative
+}
+
+//neg方法返回各个部分都取反的时长。
+func (d ISODuration) neg() ISODuration {
+	return ISODuration{Years: -d.Years, Months: -d.Months, Weeks: -d.Weeks, Days: -d.Days, Clock: -d.Clock}
+}
+
+//AddTo方法返回时间点t经过n个时长d之后的时间点，n可以是负数。日历上的时长（年、月、周、天）按照t所在的时区计算。
+func (d ISODuration) AddTo(t time.Time, n int) time.Time {
+	return t.AddDate(n*d.Years, n*d.Months, n*(7*d.Weeks+d.Days)).Add(time.Duration(n) * d.Clock)
+}
+
+//Duration方法将时长转换为固定的time.Duration，周与天分别按照7×24小时与24小时计算。
+//由于年与月的长度不固定，包含年或月的时长无法转换，返回错误。
+func (d ISODuration) Duration() (time.Duration, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, errors.New("ranges: duration " + d.String() + " has years or months and cannot be converted to time.Duration")
+	}
+	return time.Duration(7*d.Weeks+d.Days)*24*time.Hour + d.Clock, nil
+}
+
+//ISOIntervalForm定义了ISO 8601时间间隔的形式。
+type ISOIntervalForm uint8
+
+const (
+	ISOStartEnd      ISOIntervalForm = iota //start/end形式
+	ISOStartDuration                        //start/duration形式
+	ISODurationEnd                          //duration/end形式
+	ISODurationOnly                         //duration形式
+)
+
+//ISOInterval定义了ISO 8601时间间隔，Form记录了时间间隔的形式。
+//解析时，除了duration形式以外，起止时间与时长都会被计算出来，比如，解析start/duration形式时，End等于Start经过Duration之后的时间点。
+//格式化时，只使用Form形式所需要的字段。
+type ISOInterval struct {
+	Form     ISOIntervalForm
+	Start    time.Time
+	End      time.Time
+	Duration ISODuration
+}
+
+//ParseISOInterval函数解析ISO 8601时间间隔，可以是start/end、start/duration、duration/end或duration四种形式之一，
+//斜杠也可以写作“--”。起点在终点之后的时间间隔，比如，2022-01-02/2022-01-01或者带有负时长的2022-01-01/-P1D，是错误的。
+func ParseISOInterval(s string) (ISOInterval, error) {
+	var ii ISOInterval
+	first, second, found := strings.Cut(s, "/")
+	if !found {
+		first, second, found = strings.Cut(s, "--")
+	}
+	if !found {
+		d, err := ParseISODuration(s)
+		if err != nil {
+			return ii, err
+		}
+		ii.Form, ii.Duration = ISODurationOnly, d
+		return ii, nil
+	}
+	var err error
+	switch firstIsDuration, secondIsDuration := isISODuration(first), isISODuration(second); {
+	case firstIsDuration && secondIsDuration:
+		return ii, &ParseError{Text: s, Msg: "interval cannot consist of two durations"}
+	case firstIsDuration:
+		ii.Form = ISODurationEnd
+		if ii.Duration, err = ParseISODuration(first); err != nil {
+			return ii, err
+		}
+		if ii.End, err = parseISOTime(second); err != nil {
+			return ii, &ParseError{Text: s, Msg: "invalid end", Err: err}
+		}
+		ii.Start = ii.Duration.AddTo(ii.End, -1)
+	case secondIsDuration:
+		ii.Form = ISOStartDuration
+		if ii.Start, err = parseISOTime(first); err != nil {
+			return ii, &ParseError{Text: s, Msg: "invalid start", Err: err}
+		}
+		if ii.Duration, err = ParseISODuration(second); err != nil {
+			return ii, err
+		}
+		ii.End = ii.Duration.AddTo(ii.Start, 1)
+	default:
+		ii.Form = ISOStartEnd
+		if ii.Start, err = parseISOTime(first); err != nil {
+			return ii, &ParseError{Text: s, Msg: "invalid start", Err: err}
+		}
+		if ii.End, err = parseISOTime(second); err != nil {
+			return ii, &ParseError{Text: s, Msg: "invalid end", Err: err}
+		}
+		ii.Duration = ISODurationOf(ii.End.Sub(ii.Start))
+	}
+	if ii.End.Before(ii.Start) {
+		return ii, &ParseError{Text: s, Msg: "start is after end"}
+	}
+	return ii, nil
+}
+
+//isISODuration函数判断文本是否是ISO 8601时长，也就是以P开始，P之前可以带有符号。
+func isISODuration(s string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(strings.TrimPrefix(s, "+"), "-"), "P")
+}
+
+//String方法按照Form形式将时间间隔格式化为ISO 8601形式。
+func (ii ISOInterval) String() string {
+	switch ii.Form {
+	case ISOStartDuration:
+		return ii.Start.Format(ISO_TIME_LAYOUT) + "/" + ii.Duration.String()
+	case ISODurationEnd:
+		return ii.Duration.String() + "/" + ii.End.Format(ISO_TIME_LAYOUT)
+	case ISODurationOnly:
+		return ii.Duration.String()
+	default:
+		return ii.Start.Format(ISO_TIME_LAYOUT) + "/" + ii.End.Format(ISO_TIME_LAYOUT)
+	}
+}
+
+//TimeInterval方法将时间间隔转换为左闭右开的时间段[Start,End)，duration形式的时间间隔没有起止时间，无法转换，返回错误。
+//Start在End之后时同样返回错误，而不是交换二者。
+func (ii ISOInterval) TimeInterval() (TimeInterval, error) {
+	if ii.Form == ISODurationOnly {
+		return TimeInterval{}, errors.New("ranges: ISO 8601 interval " + ii.String() + " has no start or end")
+	}
+	if ii.End.Before(ii.Start) {
+		return TimeInterval{}, errors.New("ranges: the start of ISO 8601 interval " + ii.String() + " is after its end")
+	}
+	return CreateTimeInterval(ii.Start, ii.End), nil
+}
+
+//FormatISOInterval函数将时间段ti格式化为start/end形式的ISO 8601时间间隔，
+//ISO 8601时间间隔不区分边界类型，空的或者无界的时间段无法格式化，返回错误。
+func FormatISOInterval(ti TimeInterval) (string, error) {
+	ii, err := isoIntervalOf(ti)
+	if err != nil {
+		return "", err
+	}
+	return ii.String(), nil
+}
+
+//ISORepeatingInterval定义了ISO 8601重复时间间隔，Repetitions是重复次数，-1表示无限重复。
+type ISORepeatingInterval struct {
+	Repetitions int
+	Interval    ISOInterval
+}
+
+//ParseISORepeatingInterval函数解析ISO 8601重复时间间隔，比如，“R96/2022-01-01T00:00Z/PT15M”、“R/PT1H/2022-01-02T00:00Z”。
+func ParseISORepeatingInterval(s string) (ISORepeatingInterval, error) {
+	var ri ISORepeatingInterval
+	head, interval, found := strings.Cut(s, "/")
+	if !found || !strings.HasPrefix(head, "R") {
+		return ri, &ParseError{Text: s, Msg: "repeating interval must start with 'R' and '/'"}
+	}
+	ri.Repetitions = -1
+	if head != "R" {
+		n, err := strconv.Atoi(head[1:])
+		if err != nil || n < 0 {
+			return ri, &ParseError{Text: s, Msg: "invalid number of repetitions", Err: err}
+		}
+		ri.Repetitions = n
+	}
+	var err error
+	ri.Interval, err = ParseISOInterval(interval)
+	return ri, err
+}
+
+//String方法将重复时间间隔格式化为ISO 8601形式。
+func (ri ISORepeatingInterval) String() string {
+	head := "R"
+	if ri.Repetitions >= 0 {
+		head += strconv.Itoa(ri.Repetitions)
+	}
+	return head + "/" + ri.Interval.String()
+}
+
+//Cycle方法将重复时间间隔转换为第一个时间段origin与时间周期tc，二者可以直接用于cycle.NewCycleCalculator与TICycleFunc，
+//第n个周期的时间段就是origin经过n个tc之后的时间段。
+//对于duration/end形式，End是最后一个时间段的终点，所以需要给定重复次数，以便向前推算出第一个时间段。
+//由于time.Duration无法表示年和月，包含年或月的时长，以及duration形式的时间间隔都无法转换，返回错误。
+func (ri ISORepeatingInterval) Cycle() (origin TimeInterval, tc TimeCycle, err error) {
+	period, err := ri.Interval.Duration.Duration()
+	if err != nil {
+		return
+	}
+	if origin, err = ri.Interval.TimeInterval(); err != nil {
+		return
+	}
+	tc = TimeCycle{Count: 1, Unit: period}
+	if ri.Interval.Form == ISODurationEnd {
+		if ri.Repetitions < 0 {
+			err = errors.New("ranges: ISO 8601 repeating interval " + ri.String() + " has no first interval")
+			return
+		}
+		if ri.Repetitions > 1 {
+			origin = (&TICycleFunc{}).OfCycles(origin, 1-ri.Repetitions, tc)
+		}
+	}
+	return
+}
+
+//FormatISORepeatingInterval函数将第一个时间段origin、时间周期tc与重复次数n格式化为start/duration形式的
+//ISO 8601重复时间间隔，比如，R96/2022-01-01T00:00:00Z/PT15M，n为负数表示无限重复。
+//与Cycle方法相对应，时间周期tc必须与origin的时长相同。
+func FormatISORepeatingInterval(origin TimeInterval, tc TimeCycle, n int) (string, error) {
+	ii, err := isoIntervalOf(origin)
+	if err != nil {
+		return "", err
+	}
+	period := time.Duration(tc.GetCount()) * tc.GetUnit()
+	if ii.End.Sub(ii.Start) != period {
+		return "", errors.New("ranges: time cycle does not match the length of " + Tintvl2Str(origin))
+	}
+	if n < 0 {
+		n = -1
+	}
+	ii.Form = ISOStartDuration
+	return ISORepeatingInterval{Repetitions: n, Interval: ii}.String(), nil
+}
+
+//isoIntervalOf函数将时间段ti转换为start/end形式的ISO 8601时间间隔。
+func isoIntervalOf(ti TimeInterval) (ISOInterval, error) {
+	left, right := ti.Bounds()
+	if ti.IsEmpty() || left == Unbounded || right == Unbounded {
+		return ISOInterval{}, errors.New("ranges: " + Tintvl2Str(ti) + " cannot be an ISO 8601 interval")
+	}
+	start, end := ti.DeRange()
+	return ISOInterval{Form: ISOStartEnd, Start: start, End: end, Duration: ISODurationOf(end.Sub(start))}, nil
+}
+
+//parseISOTime函数依次使用isoTimeLayouts中的格式解析ISO 8601时间点。
+func parseISOTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range isoTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
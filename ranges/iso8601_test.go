@@ -0,0 +1,159 @@
+package ranges
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"com.example/common/cycle"
+)
+
+func TestISODuration(t *testing.T) {
+	for s, want := range map[string]ISODuration{
+		"PT15M":       {Clock: 15 * time.Minute},
+		"P1DT12H":     {Days: 1, Clock: 12 * time.Hour},
+		"P2W":         {Weeks: 2},
+		"P1Y2M10DT2H": {Years: 1, Months: 2, Days: 10, Clock: 2 * time.Hour},
+		"PT0.5S":      {Clock: 500 * time.Millisecond},
+		"PT1,5H":      {Clock: 90 * time.Minute},
+		"-PT1H":       {Clock: -time.Hour},
+		"-P1DT1H":     {Days: -1, Clock: -time.Hour},
+		"P1DT-1H":     {Days: 1, Clock: -time.Hour},
+	} {
+		d, err := ParseISODuration(s)
+		if err != nil || d != want {
+			t.Errorf("ParseISODuration(%q) = %+v, %v, want %+v", s, d, err, want)
+		}
+	}
+	for _, s := range []string{"", "P", "PT", "15M", "P1.5D", "PT1.5H30M", "P1H", "PT1D", "PTT1H", "-P", "P-D", "PT1-H", "--PT1H"} {
+		if _, err := ParseISODuration(s); err == nil {
+			t.Errorf("ParseISODuration(%q) should fail", s)
+		}
+	}
+	for _, s := range []string{"PT15M", "P1DT12H", "P2W", "P1Y2M10DT2H", "PT0.5S", "PT1H30M", "PT0S"} {
+		d, _ := ParseISODuration(s)
+		if d.String() != s {
+			t.Errorf("%q formats as %q", s, d.String())
+		}
+	}
+	//负的部分不能格式化为PT-1H，而是在P之前带有负号；各部分符号不同时，每个负的部分各自带有负号
+	for d, want := range map[ISODuration]string{
+		ISODurationOf(-time.Hour):                    "-PT1H",
+		ISODurationOf(-90*time.Minute - time.Second): "-PT1H30M1S",
+		{Days: -2, Clock: -time.Hour}:                "-P2DT1H",
+		{Days: 1, Clock: -90 * time.Minute}:          "P1DT-1H-30M",
+		{Years: -1, Months: 2}:                       "P-1Y2M",
+	} {
+		back, err := ParseISODuration(d.String())
+		if d.String() != want || err != nil || back != d {
+			t.Errorf("%+v formats as %q and parses back as %+v, %v", d, d.String(), back, err)
+		}
+	}
+	if _, err := (ISODuration{Months: 1}).Duration(); err == nil {
+		t.Errorf("P1M should not convert to time.Duration")
+	}
+}
+
+func TestISOInterval(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(15 * time.Minute)
+	for s, form := range map[string]ISOIntervalForm{
+		"2022-01-01T00:00Z/2022-01-01T00:15Z": ISOStartEnd,
+		"2022-01-01T00:00:00Z/PT15M":          ISOStartDuration,
+		"PT15M/2022-01-01T00:15:00Z":          ISODurationEnd,
+		"20220101T0000Z--20220101T0015Z":      ISOStartEnd,
+	} {
+		ii, err := ParseISOInterval(s)
+		if err != nil {
+			t.Errorf("ParseISOInterval(%q) failed: %v", s, err)
+			continue
+		}
+		ti, err := ii.TimeInterval()
+		if ii.Form != form || err != nil || !ti.Equal(CreateTimeInterval(start, end)) {
+			t.Errorf("ParseISOInterval(%q) = %v, %v", s, ii, err)
+		}
+	}
+	ii, err := ParseISOInterval("PT15M")
+	if err != nil || ii.Form != ISODurationOnly || ii.String() != "PT15M" {
+		t.Errorf("ParseISOInterval(PT15M) = %v, %v", ii, err)
+	}
+	if _, err = ii.TimeInterval(); err == nil {
+		t.Errorf("a duration should not convert to a time interval")
+	}
+	ii, _ = ParseISOInterval("P1M/2022-03-01T00:00:00Z")
+	if ii.String() != "P1M/2022-03-01T00:00:00Z" || !ii.Start.Equal(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("P1M/2022-03-01 parsed as %v starting at %v", ii, ii.Start)
+	}
+	//起点在终点之后的时间间隔是错误的，而不是交换起点与终点
+	for _, s := range []string{"2022-01-01T00:15Z/2022-01-01T00:00Z", "2022-01-01T00:00Z/-PT15M", "-PT15M/2022-01-01T00:00Z"} {
+		var pe *ParseError
+		if _, err := ParseISOInterval(s); !errors.As(err, &pe) {
+			t.Errorf("ParseISOInterval(%q) should fail, got %v", s, err)
+		}
+		if _, err := ParseISORepeatingInterval("R2/" + s); !errors.As(err, &pe) {
+			t.Errorf("ParseISORepeatingInterval(R2/%s) should fail, got %v", s, err)
+		}
+	}
+	if _, err := (ISOInterval{Start: end, End: start}).TimeInterval(); err == nil {
+		t.Errorf("a reversed ISOInterval should not convert to a time interval")
+	}
+	if s, err := FormatISOInterval(CreateTimeInterval(start, end)); err != nil || s != "2022-01-01T00:00:00Z/2022-01-01T00:15:00Z" {
+		t.Errorf("FormatISOInterval = %q, %v", s, err)
+	}
+	if _, err := FormatISOInterval(CreateTimeIntervalFrom(start, Closed)); err == nil {
+		t.Errorf("an unbounded time interval should not be formatted")
+	}
+	for _, s := range []string{"PT1H/PT2H", "2022-01-01/xyz", "xyz/PT1H"} {
+		if _, err := ParseISOInterval(s); err == nil {
+			t.Errorf("ParseISOInterval(%q) should fail", s)
+		}
+	}
+}
+
+func TestISORepeatingInterval(t *testing.T) {
+	ri, err := ParseISORepeatingInterval("R96/2022-01-01T00:00Z/PT15M")
+	if err != nil || ri.Repetitions != 96 || ri.String() != "R96/2022-01-01T00:00:00Z/PT15M" {
+		t.Fatalf("ParseISORepeatingInterval = %v, %v", ri, err)
+	}
+	origin, tc, err := ri.Cycle()
+	if err != nil {
+		t.Fatalf("Cycle failed: %v", err)
+	}
+	calculator := cycle.NewCycleCalculator[TimeInterval, TimeCycle](origin, tc, &TICycleFunc{})
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var last TimeInterval
+	for i := 0; i < 95; i++ {
+		_, last = calculator.Next()
+	}
+	if !last.Equal(CreateTimeInterval(start.Add(95*15*time.Minute), start.Add(24*time.Hour))) {
+		t.Errorf("the 96th interval is %s", Tintvl2Str(last))
+	}
+	s, err := FormatISORepeatingInterval(origin, tc, 96)
+	if err != nil || s != ri.String() {
+		t.Errorf("FormatISORepeatingInterval = %q, %v", s, err)
+	}
+
+	ri, _ = ParseISORepeatingInterval("R4/PT1H/2022-01-01T04:00:00Z")
+	origin, _, err = ri.Cycle()
+	if err != nil || !origin.Equal(CreateTimeInterval(start, start.Add(time.Hour))) {
+		t.Errorf("the first interval of %v is %s, %v", ri, Tintvl2Str(origin), err)
+	}
+	ri, err = ParseISORepeatingInterval("R/2022-01-01T00:00:00Z/P1D")
+	if err != nil || ri.Repetitions != -1 || ri.String() != "R/2022-01-01T00:00:00Z/P1D" {
+		t.Errorf("ParseISORepeatingInterval = %v, %v", ri, err)
+	}
+	for _, s := range []string{"R/PT1H/2022-01-01T00:00:00Z", "R1/2022-01-01T00:00:00Z/P1M", "R2/PT1H"} {
+		ri, _ := ParseISORepeatingInterval(s)
+		if _, _, err := ri.Cycle(); err == nil {
+			t.Errorf("%q should not convert to a cycle", s)
+		}
+	}
+	for _, s := range []string{"96/2022-01-01T00:00:00Z/PT15M", "Rx/PT1H", "R-1/PT1H"} {
+		if _, err := ParseISORepeatingInterval(s); err == nil {
+			t.Errorf("ParseISORepeatingInterval(%q) should fail", s)
+		}
+	}
+	if _, err := FormatISORepeatingInterval(origin, TimeCycle{Count: 2, Unit: time.Hour}, 4); err == nil {
+		t.Errorf("a mismatched cycle should not be formatted")
+	}
+}
@@ -5,22 +5,112 @@ type number interface {
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
 }
 
-//CreateNumberRange函数用于给定的两个点创建一个区间，
+//CreateNumberRange函数用于给定的两个点创建一个左闭右开区间，
 //无论两个点的大小顺序如何，创建出来的区间的起点都会小于终点。
+//对于浮点数，-Inf的起点与+Inf的终点被视为无界的端点，而有端点是NaN，或者两个端点都是+Inf或都是-Inf时，结果是空区间。
 func CreateNumberRange[P number](p1, p2 P) NumberRange[P] {
 	if p1 <= p2 {
-		return NumberRange[P]{start: p1, end: p2}
+		return newNumberRange(p1, p2, Closed, Open)
 	} else {
-		return NumberRange[P]{p2, p1}
+		return newNumberRange(p2, p1, Closed, Open)
 	}
 
 }
 
+//CreateNumberRangeWithBounds函数用于给定的两个点及其边界类型创建一个区间，
+//left是p1的边界类型，right是p2的边界类型。如果p1大于p2，两个点连同其边界类型一起交换，
+//因而，创建出来的区间的起点都会小于终点。
+//如果有端点是无界的（Unbounded），则两个点不会交换，无界端点的值被置为零值。
+//对于浮点数，-Inf的起点与+Inf的终点被视为无界的端点，比如，[-Inf,5)就是(-∞,5)，
+//+Inf的起点与-Inf的终点不包含任何数，比如，[+Inf,+Inf]与[-Inf,-Inf]，而有界的端点是NaN时，区间没有意义，二者的结果都是空区间。
+func CreateNumberRangeWithBounds[P number](p1, p2 P, left, right BoundType) NumberRange[P] {
+	if left == Unbounded || right == Unbounded || p1 <= p2 {
+		return newNumberRange(p1, p2, left, right)
+	} else {
+		return newNumberRange(p2, p1, right, left)
+	}
+}
+
+//newNumberRange函数用已经排好顺序的起点与终点及其边界类型创建区间，并处理浮点数的NaN与无穷大。
+func newNumberRange[P number](start, end P, left, right BoundType) NumberRange[P] {
+	if left != Unbounded && isNaN(start) || right != Unbounded && isNaN(end) {
+		return NumberRange[P]{}
+	}
+	//+Inf的起点与-Inf的终点不是数轴上的点，[+Inf,...)与(...,-Inf]都不包含任何数，化为空区间，
+	//因而，区间的有界端点都是有限的数，comparePoints借助Range(b,b)比较点的先后时，b也总是有限的
+	if left != Unbounded && isInf(start) {
+		if start > 0 {
+			return NumberRange[P]{}
+		}
+		left = Unbounded
+	}
+	if right != Unbounded && isInf(end) {
+		if end < 0 {
+			return NumberRange[P]{}
+		}
+		right = Unbounded
+	}
+	var zero P
+	if left == Unbounded {
+		start = zero
+	}
+	if right == Unbounded {
+		end = zero
+	}
+	if half := 0.5; P(half) == zero {
+		return canonicalIntNumberRange(start, end, left, right)
+	}
+	return NumberRange[P]{start: start, end: end, bounds: makeBoundPair(left, right)}
+}
+
+//canonicalIntNumberRange函数将整数类型的区间化为规范形式：开边界的起点化为后继整数的闭边界，
+//闭边界的终点化为后继整数的开边界，比如，[1,3]、(0,3]与(0,4)都化为[1,4)。
+//类型P的最大值没有后继，作为终点时仍然是闭边界，比如，[250,255]（P为uint8时），而作为开边界的起点时，区间是空的。
+func canonicalIntNumberRange[P number](start, end P, left, right BoundType) NumberRange[P] {
+	if left == Open || right == Closed {
+		_, max := integerLimits[P]()
+		if left == Open {
+			if start == max {
+				return NumberRange[P]{}
+			}
+			start, left = start+1, Closed
+		}
+		if right == Closed && end != max {
+			end, right = end+1, Open
+		}
+	}
+	if left != Unbounded && right != Unbounded && end < start {
+		end = start
+	}
+	return NumberRange[P]{start: start, end: end, bounds: makeBoundPair(left, right)}
+}
+
+//CreateNumberRangeFrom函数创建一个以start为起点、终点无界的区间，比如，[start,+∞)，
+//left是起点的边界类型。
+func CreateNumberRangeFrom[P number](start P, left BoundType) NumberRange[P] {
+	return CreateNumberRangeWithBounds(start, start, left, Unbounded)
+}
+
+//CreateNumberRangeTo函数创建一个起点无界、以end为终点的区间，比如，(-∞,end)，
+//right是终点的边界类型。
+func CreateNumberRangeTo[P number](end P, right BoundType) NumberRange[P] {
+	return CreateNumberRangeWithBounds(end, end, Unbounded, right)
+}
+
+//CreateUnboundedNumberRange函数创建一个起点与终点都无界的区间(-∞,+∞)，该区间包含所有的点。
+func CreateUnboundedNumberRange[P number]() NumberRange[P] {
+	var zero P
+	return CreateNumberRangeWithBounds(zero, zero, Unbounded, Unbounded)
+}
+
 //NumberRange[P number] 定义了各种数字类型元素组成的区间类型，该类型的区间
-//满足Range[P, NumberRange[P]接口。
+//满足Range[P, NumberRange[P]接口。零值的NumberRange是左闭右开区间。
+//P是整数类型时，区间被化为规范形式，参见canonicalIntNumberRange，因而，包含相同整数的[1,3]、[1,4)与(0,3]都是[1,4)，
+//彼此相等，[1,2]与[3,4]这样在整数上相邻的区间也可以被合并为[1,5)。需要Count与Walk时，可以用IntRangeOf函数转换为IntRange。
 type NumberRange[P number] struct {
-	start P
-	end   P
+	start  P
+	end    P
+	bounds boundPair //起点与终点的边界类型
 }
 
 func (nr NumberRange[P]) Range(start, end P) NumberRange[P] {
@@ -28,27 +118,48 @@ func (nr NumberRange[P]) Range(start, end P) NumberRange[P] {
 	return result
 }
 
+func (nr NumberRange[P]) RangeWithBounds(start, end P, left, right BoundType) NumberRange[P] {
+	return CreateNumberRangeWithBounds(start, end, left, right)
+}
+
 func (nr NumberRange[P]) DeRange() (start, end P) {
 	return nr.start, nr.end
 }
 
+func (nr NumberRange[P]) Bounds() (left, right BoundType) {
+	return nr.bounds.types()
+}
+
 func (nr NumberRange[P]) IsIncludedPoint(p P) bool {
-	return p >= nr.start && p < nr.end
+	left, right := nr.Bounds()
+	return (left == Unbounded || p > nr.start || p == nr.start && left == Closed) &&
+		(right == Unbounded || p < nr.end || p == nr.end && right == Closed)
 }
 func (nr NumberRange[P]) IsBeforePoint(p P) bool {
-	return p >= nr.end
+	_, right := nr.Bounds()
+	return right != Unbounded && (p > nr.end || p == nr.end && right == Open)
 }
 func (nr NumberRange[P]) IsAfterPoint(p P) bool {
-	return p < nr.start
+	left, _ := nr.Bounds()
+	return left != Unbounded && (p < nr.start || p == nr.start && left == Open)
 }
 
 ////////////////////////////////////////////////////////////////////
+func (nr NumberRange[P]) IsEmpty() bool {
+	return IsEmpty[P, NumberRange[P]](nr)
+}
+//IsPoint方法判断区间是否是一个点，整数区间只包含一个整数时也是一个点，比如，[3,4)。
 func (nr NumberRange[P]) IsPoint() bool {
+	var zero P
+	if half := 0.5; P(half) == zero && nr.bounds == makeBoundPair(Closed, Open) {
+		return nr.start < nr.end && nr.start+1 == nr.end
+	}
 	return IsPoint[P, NumberRange[P]](nr)
 }
 func (nr NumberRange[P]) String() string {
 	return RngToStr[P, NumberRange[P]](nr, v2s[P])
 }
+//Equal方法按照端点及其边界类型判断两个区间是否相等，整数区间已经是规范形式，因而包含相同整数的区间相等。
 func (nr NumberRange[P]) Equal(other NumberRange[P]) bool {
 	return Equal[P, NumberRange[P]](nr, other)
 }
@@ -75,6 +186,9 @@ func (nr NumberRange[P]) UnionOthers(others []NumberRange[P]) (bool, NumberRange
 func (nr NumberRange[P]) Except(other NumberRange[P]) (r1, r2 NumberRange[P]) {
 	return Except[P, NumberRange[P]](nr, other)
 }
+func (nr NumberRange[P]) ExceptOthers(others []NumberRange[P]) []NumberRange[P] {
+	return ExceptOthers[P, NumberRange[P]](nr, others)
+}
 func (nr NumberRange[P]) IsBefore(other NumberRange[P]) bool {
 	return IsBefore[P, NumberRange[P]](nr, other)
 }
@@ -83,6 +197,27 @@ func (nr NumberRange[P]) IsAfter(other NumberRange[P]) bool {
 	return IsAfter[P, NumberRange[P]](nr, other)
 }
 
+//MarshalJSON方法将区间编码为JSON对象，比如，{"start":1,"end":5}，
+//非缺省的边界类型编码为bounds字段，比如，{"start":1,"end":5,"bounds":"[]"}，无界的端点不被编码。
+func (nr NumberRange[P]) MarshalJSON() ([]byte, error) {
+	return marshalRangeJSON[P, NumberRange[P]](nr)
+}
+
+//UnmarshalJSON方法将JSON数据解码为区间，JSON数据既可以是对象形式，比如，{"start":1,"end":5}，
+//也可以是字符串形式，比如，"[1,5)"，字符串形式的端点与ParseNumberRange的规则相同，比如，"[NaN,5)"是错误的。
+//JSON数据为null时，区间保持不变。
+func (nr *NumberRange[P]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	result, err := unmarshalRangeJSONWith[P, NumberRange[P]](*nr, data, parseNumber[P])
+	if err != nil {
+		return err
+	}
+	*nr = result
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type NumCycle[P number] struct {
@@ -105,7 +240,8 @@ func (nc *NRCycleFunc[P]) OfCycles(t NumberRange[P], n int, c NumCycle[P]) Numbe
 	var mount = P(n) * P(c.Count) * c.Unit
 	start := rStart + mount
 	end := rEnd + mount
-	return t.Range(start, end)
+	left, right := t.Bounds()
+	return t.RangeWithBounds(start, end, left, right)
 }
 
 //
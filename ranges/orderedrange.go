@@ -0,0 +1,234 @@
+package ranges
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+//ordered约束了可以使用<、<=、>、>=进行比较的类型，也就是各种数字类型与字符串类型。
+type ordered interface {
+	number | ~string
+}
+
+//CreateOrderedRange函数用于给定的两个点创建一个左闭右开区间，
+//无论两个点的大小顺序如何，创建出来的区间的起点都会小于终点。
+func CreateOrderedRange[P ordered](p1, p2 P) OrderedRange[P] {
+	if p1 <= p2 {
+		return OrderedRange[P]{start: p1, end: p2}
+	} else {
+		return OrderedRange[P]{start: p2, end: p1}
+	}
+}
+
+//CreateOrderedRangeWithBounds函数用于给定的两个点及其边界类型创建一个区间，
+//left是p1的边界类型，right是p2的边界类型。如果p1大于p2，两个点连同其边界类型一起交换，
+//因而，创建出来的区间的起点都会小于终点。
+//如果有端点是无界的（Unbounded），则两个点不会交换，无界端点的值被置为零值。
+func CreateOrderedRangeWithBounds[P ordered](p1, p2 P, left, right BoundType) OrderedRange[P] {
+	if left == Unbounded || right == Unbounded {
+		var zero P
+		if left == Unbounded {
+			p1 = zero
+		}
+		if right == Unbounded {
+			p2 = zero
+		}
+		return OrderedRange[P]{start: p1, end: p2, bounds: makeBoundPair(left, right)}
+	}
+	if p1 <= p2 {
+		return OrderedRange[P]{start: p1, end: p2, bounds: makeBoundPair(left, right)}
+	} else {
+		return OrderedRange[P]{start: p2, end: p1, bounds: makeBoundPair(right, left)}
+	}
+}
+
+//CreateOrderedRangeFrom函数创建一个以start为起点、终点无界的区间，比如，[start,+∞)，
+//left是起点的边界类型。
+func CreateOrderedRangeFrom[P ordered](start P, left BoundType) OrderedRange[P] {
+	return CreateOrderedRangeWithBounds(start, start, left, Unbounded)
+}
+
+//CreateOrderedRangeTo函数创建一个起点无界、以end为终点的区间，比如，(-∞,end)，
+//right是终点的边界类型。
+func CreateOrderedRangeTo[P ordered](end P, right BoundType) OrderedRange[P] {
+	return CreateOrderedRangeWithBounds(end, end, Unbounded, right)
+}
+
+//CreateUnboundedOrderedRange函数创建一个起点与终点都无界的区间(-∞,+∞)，该区间包含所有的点。
+func CreateUnboundedOrderedRange[P ordered]() OrderedRange[P] {
+	var zero P
+	return CreateOrderedRangeWithBounds(zero, zero, Unbounded, Unbounded)
+}
+
+//OrderedRange[P ordered]定义了任何可以使用<、<=、>、>=进行比较的类型的元素组成的区间类型，
+//与NumberRange不同，它的元素还可以是字符串，字符串按照字典序（逐字节）比较。该类型的区间满足Range[P, OrderedRange[P]]接口，
+//零值的OrderedRange是左闭右开区间。元素是字符串时，区间化为字符串时端点带有引号，比如，["abc","abd")。
+type OrderedRange[P ordered] struct {
+	start  P
+	end    P
+	bounds boundPair //起点与终点的边界类型
+}
+
+func (or OrderedRange[P]) Range(start, end P) OrderedRange[P] {
+	return CreateOrderedRange(start, end)
+}
+
+func (or OrderedRange[P]) RangeWithBounds(start, end P, left, right BoundType) OrderedRange[P] {
+	return CreateOrderedRangeWithBounds(start, end, left, right)
+}
+
+func (or OrderedRange[P]) DeRange() (start, end P) {
+	return or.start, or.end
+}
+
+func (or OrderedRange[P]) Bounds() (left, right BoundType) {
+	return or.bounds.types()
+}
+
+func (or OrderedRange[P]) IsIncludedPoint(p P) bool {
+	left, right := or.Bounds()
+	return (left == Unbounded || p > or.start || p == or.start && left == Closed) &&
+		(right == Unbounded || p < or.end || p == or.end && right == Closed)
+}
+func (or OrderedRange[P]) IsBeforePoint(p P) bool {
+	_, right := or.Bounds()
+	return right != Unbounded && (p > or.end || p == or.end && right == Open)
+}
+func (or OrderedRange[P]) IsAfterPoint(p P) bool {
+	left, _ := or.Bounds()
+	return left != Unbounded && (p < or.start || p == or.start && left == Open)
+}
+
+////////////////////////////////////////////////////////////////////
+func (or OrderedRange[P]) IsEmpty() bool {
+	return IsEmpty[P, OrderedRange[P]](or)
+}
+func (or OrderedRange[P]) IsPoint() bool {
+	return IsPoint[P, OrderedRange[P]](or)
+}
+func (or OrderedRange[P]) String() string {
+	return RngToStr[P, OrderedRange[P]](or, orderedToStr[P])
+}
+func (or OrderedRange[P]) Equal(other OrderedRange[P]) bool {
+	return Equal[P, OrderedRange[P]](or, other)
+}
+func (or OrderedRange[P]) IsIntersected(other OrderedRange[P]) bool {
+	return IsIntersected[P, OrderedRange[P]](or, other)
+}
+func (or OrderedRange[P]) Intersect(other OrderedRange[P]) (bool, OrderedRange[P]) {
+	return Intersect[P, OrderedRange[P]](or, other)
+}
+func (or OrderedRange[P]) IntersectOthers(others []OrderedRange[P]) (bool, OrderedRange[P]) {
+	return IntersectOthers[P, OrderedRange[P]](or, others)
+}
+func (or OrderedRange[P]) Union(other OrderedRange[P]) (bool, OrderedRange[P]) {
+	return Union[P, OrderedRange[P]](or, other)
+}
+func (or OrderedRange[P]) UnionOthers(others []OrderedRange[P]) (bool, OrderedRange[P]) {
+	return UnionOthers[P, OrderedRange[P]](or, others)
+}
+func (or OrderedRange[P]) Except(other OrderedRange[P]) (r1, r2 OrderedRange[P]) {
+	return Except[P, OrderedRange[P]](or, other)
+}
+func (or OrderedRange[P]) ExceptOthers(others []OrderedRange[P]) []OrderedRange[P] {
+	return ExceptOthers[P, OrderedRange[P]](or, others)
+}
+func (or OrderedRange[P]) IsBefore(other OrderedRange[P]) bool {
+	return IsBefore[P, OrderedRange[P]](or, other)
+}
+func (or OrderedRange[P]) IsAfter(other OrderedRange[P]) bool {
+	return IsAfter[P, OrderedRange[P]](or, other)
+}
+
+//MarshalJSON方法将区间编码为JSON对象，比如，{"start":"abc","end":"abd"}，编码规则与NumberRange相同。
+//JSON字符串只能是UTF-8文本，所以，端点含有非UTF-8字节时，区间被编码为其字符串形式（参见String方法），
+//比如，"[\"ab\\xff\",\"ac\")"，非UTF-8字节被转义为“\xff”，从而可以无损地解码。
+func (or OrderedRange[P]) MarshalJSON() ([]byte, error) {
+	if !or.isValidUTF8() {
+		return json.Marshal(or.String())
+	}
+	return marshalRangeJSON[P, OrderedRange[P]](or)
+}
+
+//UnmarshalJSON方法将JSON数据解码为区间，解码规则与NumberRange相同。JSON数据为null时，区间保持不变。
+func (or *OrderedRange[P]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	result, err := unmarshalRangeJSON[P, OrderedRange[P]](*or, data)
+	if err != nil {
+		return err
+	}
+	*or = result
+	return nil
+}
+
+//isValidUTF8方法判断区间有界的端点是否都是有效的UTF-8文本，数字类型的端点总是有效的。
+func (or OrderedRange[P]) isValidUTF8() bool {
+	left, right := or.Bounds()
+	for _, e := range []endpoint[P]{{or.start, left}, {or.end, right}} {
+		if v := reflect.ValueOf(e.value); e.bound != Unbounded && v.Kind() == reflect.String && !utf8.ValidString(v.String()) {
+			return false
+		}
+	}
+	return true
+}
+
+//orderedToStr函数将点p转换为字符串，字符串类型的点使用strconv.Quote加上引号，以便区分空字符串与无界端点，
+//并将不可打印的字节转义为“\xff”等形式，ParseKeyRange函数与UnmarshalJSON方法使用同样的规则还原这些字节。
+func orderedToStr[P ordered](p P) string {
+	if v := reflect.ValueOf(p); v.Kind() == reflect.String {
+		return strconv.Quote(v.String())
+	}
+	return v2s(p)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+//KeyRange是由字符串键组成的区间类型，键按照字典序（逐字节）比较，与bytes.Compare的结果相同，
+//因而，字节切片形式的键可以转换为字符串之后使用KeyRange，比如，使用KeyRange划分键空间（key space）。
+type KeyRange = OrderedRange[string]
+
+//CreateKeyRange函数用字节切片形式的两个键创建一个左闭右开区间[start,end)。
+func CreateKeyRange(start, end []byte) KeyRange {
+	return CreateOrderedRange(string(start), string(end))
+}
+
+//ParseKeyRange函数解析KeyRange的文本形式，它与String方法互逆，比如，ParseKeyRange(`["ab\xff","ac")`)，
+//带引号的键按照Go的字符串字面量还原，因而，非UTF-8字节的键也可以无损地往返。
+func ParseKeyRange(s string) (KeyRange, error) {
+	return ParseRange[string, KeyRange](s, textToPoint[string])
+}
+
+//IsIncludedKey函数判断字节切片形式的键key是否属于区间kr。
+func IsIncludedKey(kr KeyRange, key []byte) bool {
+	return kr.IsIncludedPoint(string(key))
+}
+
+//PrefixRange函数返回以prefix为前缀的所有键构成的区间，比如，"abc"对应的区间是["abc","abd")。
+//如果不存在比所有这些键都大的最小键（prefix为空或者全部由0xff构成），则区间的终点是无界的，比如，""对应的区间是["",+∞)。
+func PrefixRange(prefix string) KeyRange {
+	if end, ok := PrefixSuccessor(prefix); ok {
+		return CreateOrderedRange(prefix, end)
+	}
+	return CreateOrderedRangeFrom(prefix, Closed)
+}
+
+//PrefixSuccessor函数返回大于所有以prefix为前缀的键的最小键，也就是去掉末尾的0xff之后，将最后一个字节加1，
+//比如，"abc"的结果是"abd"，"ab\xff"的结果是"ac"。如果这样的键不存在（prefix为空或者全部由0xff构成），返回false。
+func PrefixSuccessor(prefix string) (string, bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			return prefix[:i] + string([]byte{prefix[i] + 1}), true
+		}
+	}
+	return "", false
+}
+
+//KeySuccessor函数返回按照字典序紧随key之后的键，也就是在key的末尾加上一个0字节，
+//因而，[key,KeySuccessor(key))是只包含key的左闭右开区间。
+func KeySuccessor(key string) string {
+	return key + "\x00"
+}
@@ -0,0 +1,82 @@
+package ranges
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderedRange(t *testing.T) {
+	r := CreateOrderedRange("m", "c")
+	if r.String() != `["c","m")` || !r.IsIncludedPoint("dog") || r.IsIncludedPoint("m") || r.IsIncludedPoint("b") {
+		t.Errorf("%s: wrong result", r.String())
+	}
+	if ok, u := r.Union(CreateOrderedRangeWithBounds("m", "t", Closed, Closed)); !ok || u.String() != `["c","t"]` {
+		t.Errorf("Union: got %s, %v", u.String(), ok)
+	}
+	r1, r2 := CreateOrderedRange("a", "z").Except(CreateOrderedRange("c", "m"))
+	if r1.String() != `["a","c")` || r2.String() != `["m","z")` {
+		t.Errorf("Except: got %s and %s", r1.String(), r2.String())
+	}
+	if s := CreateOrderedRangeFrom("", Closed).String(); s != `["",+∞)` {
+		t.Errorf("String: got %s", s)
+	}
+	if s := CreateOrderedRange(1.5, 0.5).String(); s != "[0.5,1.5)" {
+		t.Errorf("String: got %s", s)
+	}
+	set := NewRangeSet[string, KeyRange](PrefixRange("user/"), PrefixRange("order/"), PrefixRange("user/42/"))
+	if set.String() != `{["order/","order0"),["user/","user0")}` || !set.Contains("user/7") || set.Contains("users") {
+		t.Errorf("RangeSet: got %s", set.String())
+	}
+
+	data, err := json.Marshal(CreateOrderedRange("abc", "abd"))
+	if err != nil || string(data) != `{"start":"abc","end":"abd"}` {
+		t.Errorf("MarshalJSON: got %s, %v", data, err)
+	}
+	var decoded KeyRange
+	if err := json.Unmarshal([]byte(`"[\"a,b\",\"c\"]"`), &decoded); err != nil || decoded.String() != `["a,b","c"]` {
+		t.Errorf("UnmarshalJSON: got %s, %v", decoded.String(), err)
+	}
+}
+
+func TestKeyRange(t *testing.T) {
+	for prefix, want := range map[string]string{
+		"abc":       `["abc","abd")`,
+		"ab\xff":    `["ab\xff","ac")`,
+		"\xff\xff":  `["\xff\xff",+∞)`,
+		"":          `["",+∞)`,
+		"a\x00\xff": `["a\x00\xff","a\x01")`,
+	} {
+		if got := PrefixRange(prefix).String(); got != want {
+			t.Errorf("PrefixRange(%q) = %s, want %s", prefix, got, want)
+		}
+	}
+	kr := CreateKeyRange([]byte{0x01, 0x02}, []byte{0x01, 0x03})
+	if !IsIncludedKey(kr, []byte{0x01, 0x02, 0xff}) || IsIncludedKey(kr, []byte{0x01, 0x03}) || IsIncludedKey(kr, []byte{0x01}) {
+		t.Errorf("IsIncludedKey: wrong result for %s", kr.String())
+	}
+	//非UTF-8字节的键经过文本与JSON都可以无损地往返
+	for _, r := range []KeyRange{PrefixRange("ab\xff"), PrefixRange("\xff\xfe"), CreateOrderedRange("a,\"b", "\x00\x80é"), PrefixRange("")} {
+		back, err := ParseKeyRange(r.String())
+		if err != nil || back != r {
+			t.Errorf("ParseKeyRange(%s) = %s, %v", r.String(), back.String(), err)
+		}
+		data, err := json.Marshal(r)
+		var decoded KeyRange
+		if err == nil {
+			err = json.Unmarshal(data, &decoded)
+		}
+		if err != nil || decoded != r {
+			t.Errorf("JSON round trip of %s: got %s via %s, %v", r.String(), decoded.String(), data, err)
+		}
+	}
+	if data, _ := json.Marshal(PrefixRange("ab\xff")); string(data) != `"[\"ab\\xff\",\"ac\")"` {
+		t.Errorf("MarshalJSON of a non-UTF-8 key: got %s", data)
+	}
+	if r, err := ParseKeyRange(`["\/a","\u00e9")`); err != nil || r != CreateOrderedRange("/a", "é") {
+		t.Errorf("ParseKeyRange with JSON escapes: got %s, %v", r.String(), err)
+	}
+	single := CreateOrderedRange("abc", KeySuccessor("abc"))
+	if !single.IsIncludedPoint("abc") || single.IsIncludedPoint("abc\x00") || single.IsIncludedPoint("abca") {
+		t.Errorf("KeySuccessor: wrong result for %s", single.String())
+	}
+}
@@ -0,0 +1,184 @@
+package ranges
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ParseError是解析区间文本时发生的错误，记录了被解析的文本、错误原因以及解析端点时发生的错误。
+type ParseError struct {
+	Text string //被解析的文本
+	Msg  string //错误原因
+	Err  error  //解析端点时发生的错误，可能为nil
+}
+
+func (e *ParseError) Error() string {
+	msg := "ranges: cannot parse " + strconv.Quote(e.Text) + ": " + e.Msg
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+//Unwrap方法返回解析端点时发生的错误。
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+//ParseRange函数解析区间的文本形式，返回类型为R的区间。区间的文本形式与RngToStr函数的输出相同，
+//也就是String方法的输出，因而，ParseRange可以与String方法互逆。其中：
+//    - 左括号“[”或“(”表示起点是闭边界或开边界，右括号“]”或“)”表示终点是闭边界或开边界；
+//    - 起点与终点之间用逗号分隔，括号、逗号与端点之间可以有空白字符；
+//    - 无界的起点可以写作“-∞”、“-inf”、“-infinity”或者空白，无界的终点可以写作“+∞”、“∞”、“+inf”、“inf”、
+//      “+infinity”、“infinity”或者空白，不区分大小写；
+//    - 空区间写作“empty”。
+//parsePoint函数负责将端点的文本转换为类型P的值。如果端点的文本中含有逗号，ParseRange会依次尝试每一个逗号，
+//直到起点与终点都能被parsePoint成功转换为止。起点在终点之后的文本，比如“[5,1)”，是错误的。
+func ParseRange[P any, R any](s string, parsePoint func(string) (P, error)) (R, error) {
+	var proto R
+	rt, err := parseRangeText(s, parsePoint)
+	if err != nil {
+		return proto, err
+	}
+	if err = checkRangeText(typeTo[R, Range[P, R]](proto), rt, s); err != nil {
+		return proto, err
+	}
+	return fromRangeText(typeTo[R, Range[P, R]](proto), rt), nil
+}
+
+//ParseNumberRange函数解析数字区间的文本形式，比如“[1,5)”、“(0.5, 2.5]”、“[100,+∞)”。
+func ParseNumberRange[P number](s string) (NumberRange[P], error) {
+	return ParseRange[P, NumberRange[P]](s, parseNumber[P])
+}
+
+//ParseTimeInterval函数使用给定的时间格式layout解析时间段的文本形式，
+//比如，ParseTimeInterval(TIME_LAYOUT_SECOND, "[2022-01-01 00:00:00,2022-01-02 00:00:00)")，
+//它与FmtTintvl函数互逆。
+func ParseTimeInterval(layout, s string) (TimeInterval, error) {
+	return ParseRange[time.Time, TimeInterval](s, func(text string) (time.Time, error) {
+		return time.Parse(layout, text)
+	})
+}
+
+//rangeText是区间的文本形式经过解析之后得到的结果，由下端点、上端点以及是否为空区间构成。
+type rangeText[P any] struct {
+	lower, upper endpoint[P]
+	empty        bool
+}
+
+//parseRangeText函数解析区间的文本形式，其中，parsePoint函数负责将端点的文本转换为类型P的值。
+func parseRangeText[P any](s string, parsePoint func(string) (P, error)) (rangeText[P], error) {
+	var result rangeText[P]
+	text := strings.TrimSpace(s)
+	if strings.EqualFold(text, EmptyRangeString) {
+		result.empty = true
+		return result, nil
+	}
+	if len(text) < 2 {
+		return result, &ParseError{Text: s, Msg: "range text is too short"}
+	}
+	switch text[0] {
+	case '[':
+		result.lower.bound = Closed
+	case '(':
+		result.lower.bound = Open
+	default:
+		return result, &ParseError{Text: s, Msg: "range must start with '[' or '('"}
+	}
+	switch text[len(text)-1] {
+	case ']':
+		result.upper.bound = Closed
+	case ')':
+		result.upper.bound = Open
+	default:
+		return result, &ParseError{Text: s, Msg: "range must end with ']' or ')'"}
+	}
+	inner := text[1 : len(text)-1]
+	var firstErr error
+	for i := 0; i < len(inner); i++ {
+		if inner[i] != ',' {
+			continue
+		}
+		lower, lowerErr := parseEndpoint(inner[:i], result.lower.bound, isNegativeInfinity, parsePoint)
+		upper, upperErr := parseEndpoint(inner[i+1:], result.upper.bound, isPositiveInfinity, parsePoint)
+		if lowerErr == nil && upperErr == nil {
+			result.lower, result.upper = lower, upper
+			return result, nil
+		}
+		if firstErr == nil {
+			firstErr = lowerErr
+			if firstErr == nil {
+				firstErr = upperErr
+			}
+		}
+	}
+	if firstErr != nil {
+		return result, &ParseError{Text: s, Msg: "invalid endpoint", Err: firstErr}
+	}
+	return result, &ParseError{Text: s, Msg: "missing ',' between start and end"}
+}
+
+//parseEndpoint函数解析一个端点的文本，isInfinity函数判断文本是否表示该端点是无界的。
+func parseEndpoint[P any](text string, bound BoundType, isInfinity func(string) bool,
+	parsePoint func(string) (P, error)) (endpoint[P], error) {
+	text = strings.TrimSpace(text)
+	if isInfinity(text) {
+		return endpoint[P]{bound: Unbounded}, nil
+	}
+	value, err := parsePoint(text)
+	return endpoint[P]{value: value, bound: bound}, err
+}
+
+//isNegativeInfinity函数判断文本是否表示无界的起点（负无穷）。
+func isNegativeInfinity(text string) bool {
+	switch strings.ToLower(text) {
+	case "", NegativeInfinity, "-inf", "-infinity":
+		return true
+	}
+	return false
+}
+
+//isPositiveInfinity函数判断文本是否表示无界的终点（正无穷）。
+func isPositiveInfinity(text string) bool {
+	switch strings.ToLower(text) {
+	case "", PositiveInfinity, "∞", "+inf", "inf", "+infinity", "infinity":
+		return true
+	}
+	return false
+}
+
+//parseNumber函数将文本转换为数字类型P的值，文本表示的数字超出类型P的范围时返回错误，比如，float32的1e39。
+//NaN不是一个有意义的端点，文本表示NaN时返回ErrNaN，因而，ParseNumberRange[float64]("[NaN,5)")返回包装了ErrNaN的*ParseError。
+//类似地，文本表示+Inf或-Inf时返回ErrInfinity，无界的端点应该使用-∞、+∞等形式，参见ParseRange。
+func parseNumber[P number](text string) (P, error) {
+	var zero P
+	half := 0.5
+	if P(half) != zero {
+		f, err := strconv.ParseFloat(text, 64)
+		switch {
+		case err != nil:
+			return zero, err
+		case f != f:
+			return zero, ErrNaN
+		case math.IsInf(f, 0):
+			return zero, ErrInfinity
+		case math.IsInf(float64(P(f)), 0):
+			return zero, &strconv.NumError{Func: "ParseFloat", Num: text, Err: strconv.ErrRange}
+		}
+		return P(f), nil
+	}
+	if zero-1 < zero {
+		i, err := strconv.ParseInt(text, 0, 64)
+		if err == nil && int64(P(i)) != i {
+			err = &strconv.NumError{Func: "ParseInt", Num: text, Err: strconv.ErrRange}
+		}
+		return P(i), err
+	}
+	u, err := strconv.ParseUint(text, 0, 64)
+	if err == nil && uint64(P(u)) != u {
+		err = &strconv.NumError{Func: "ParseUint", Num: text, Err: strconv.ErrRange}
+	}
+	return P(u), err
+}
@@ -0,0 +1,68 @@
+package ranges
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	valid := map[string]string{
+		"[1,5)":         "[1,5)",
+		" ( 1 , 5 ] ":   "[2,6)",
+		"[-3,-1]":       "[-3,0)",
+		"(-∞,100)":      "(-∞,100)",
+		"[10, +INF)":    "[10,+∞)",
+		"(-infinity,∞)": "(-∞,+∞)",
+		"[,5)":          "(-∞,5)",
+		"EMPTY":         "empty",
+		"[0x10,0x20)":   "[16,32)",
+	}
+	for s, want := range valid {
+		r, err := ParseNumberRange[int](s)
+		if err != nil || r.String() != want {
+			t.Errorf("ParseNumberRange(%q): got %s, %v", s, r.String(), err)
+		}
+	}
+	for _, r := range []NumberRange[float64]{
+		CreateNumberRange(1.25, 1e6), CreateNumberRangeWithBounds(-0.5, 0.5, Open, Closed),
+		CreateNumberRangeFrom(3.0, Open), CreateUnboundedNumberRange[float64](), {},
+	} {
+		if back, err := ParseNumberRange[float64](r.String()); err != nil || !back.Equal(r) {
+			t.Errorf("round trip of %s: got %s, %v", r.String(), back.String(), err)
+		}
+	}
+	invalid := []string{"", "1,5", "[1,5", "{1,5)", "[1 5)", "[a,5)", "[+∞,5)", "[1,-∞)", "[1,300]", "[5,1)", "(5,1]"}
+	for _, s := range invalid {
+		_, err := ParseNumberRange[int8](s)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseNumberRange(%q) should fail with ParseError, got %v", s, err)
+		}
+	}
+	_, err := ParseNumberRange[uint8]("[1,300]")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("ParseNumberRange out of range: got %v", err)
+	}
+
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	ti := CreateTimeInterval(day, day.Add(36*time.Hour))
+	back, err := ParseTimeInterval(TIME_LAYOUT_SECOND, Tintvl2Str(ti))
+	if err != nil || !back.Equal(ti) {
+		t.Errorf("ParseTimeInterval: got %s, %v", Tintvl2Str(back), err)
+	}
+	const layout = "Jan 2, 2006"
+	back, err = ParseTimeInterval(layout, "[Jan 1, 2022, Jan 2, 2022]")
+	if err != nil || FmtTintvl(back, layout) != "[Jan 1, 2022,Jan 2, 2022]" {
+		t.Errorf("ParseTimeInterval with commas: got %s, %v", FmtTintvl(back, layout), err)
+	}
+	back, err = ParseTimeInterval("2006-01-02", "[2022-01-01,+∞)")
+	if err != nil || !back.Equal(CreateTimeIntervalFrom(day, Closed)) {
+		t.Errorf("ParseTimeInterval unbounded: got %s, %v", Tintvl2Str(back), err)
+	}
+	r, err := ParseRange[int, NumberRange[int]]("[1,3]", strconv.Atoi)
+	if err != nil || !r.Equal(CreateNumberRangeWithBounds(1, 3, Closed, Closed)) {
+		t.Errorf("ParseRange: got %s, %v", r.String(), err)
+	}
+}
@@ -0,0 +1,335 @@
+package ranges
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"errors"
+	"strings"
+	"time"
+)
+
+/**
+本文件实现了PostgreSQL范围类型（int4range、int8range、numrange、tsrange、tstzrange、daterange）
+以及多范围类型（int4multirange等）的字面量与区间之间的转换，从而使NumberRange、SeqRange（包括TimeInterval）
+实现了sql.Scanner与driver.Valuer接口，RangeSet实现了多范围字面量的转换。
+PostgreSQL范围字面量的语法如下：
+    empty                      空区间
+    [lower,upper)              括号表示边界类型，与ranges包的文本形式相同
+    (,upper]  或  [lower,)     省略端点的值表示该端点无界
+    ["2022-01-01 00:00:00+00","2022-01-02 00:00:00+00")
+                               端点的值可以用双引号括起来，双引号内用""或者\"表示双引号，用\\表示反斜杠
+多范围字面量是用大括号括起来、用逗号分隔的若干范围字面量，比如{[1,3),[5,7)}，{}表示空集合。
+**/
+
+//PG_TIME_LAYOUT是区间转换为PostgreSQL范围字面量时，time.Time类型的端点所使用的时间格式。
+const PG_TIME_LAYOUT = "2006-01-02 15:04:05.999999Z07:00"
+
+//pgTimeLayouts是解析PostgreSQL范围字面量时，time.Time类型的端点可以使用的时间格式，
+//依次对应tstzrange（整点时区与非整点时区）、tsrange、RFC 3339以及daterange的输出格式。
+//解析时，秒之后的小数部分总是可以被识别，不需要在格式中指定。
+var pgTimeLayouts = []string{
+	"2006-01-02 15:04:05Z07",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07:00:00",
+	"2006-01-02 15:04:05",
+	time.RFC3339Nano,
+	"2006-01-02",
+}
+
+//Scan方法实现了sql.Scanner接口，将PostgreSQL的int4range、int8range或numrange字面量转换为区间。
+func (nr *NumberRange[P]) Scan(src interface{}) error {
+	result, err := scanPgRange[P, NumberRange[P]](src, parseNumber[P])
+	if err != nil {
+		return err
+	}
+	*nr = result
+	return nil
+}
+
+//Value方法实现了driver.Valuer接口，将区间转换为PostgreSQL范围字面量，比如，[1,5)、(,5]、empty。
+func (nr NumberRange[P]) Value() (driver.Value, error) {
+	return formatPgRange[P, NumberRange[P]](nr, v2s[P]), nil
+}
+
+//Scan方法实现了sql.Scanner接口，将PostgreSQL范围字面量转换为区间，比如，将tstzrange字面量转换为TimeInterval。
+//time.Time类型的端点按照PostgreSQL的输出格式进行解析，其他类型的端点如果实现了encoding.TextUnmarshaler接口，
+//则使用该接口进行解析。
+func (sr *SeqRange[P, T]) Scan(src interface{}) error {
+	result, err := scanPgRange[P, SeqRange[P, T]](src, parsePgPoint[P])
+	if err != nil {
+		return err
+	}
+	*sr = result
+	return nil
+}
+
+//Value方法实现了driver.Valuer接口，将区间转换为PostgreSQL范围字面量，端点的值总是用双引号括起来，
+//time.Time类型的端点使用PG_TIME_LAYOUT格式，比如，["2022-01-01 00:00:00Z","2022-01-02 00:00:00Z")。
+func (sr SeqRange[P, T]) Value() (driver.Value, error) {
+	return formatPgRange[P, SeqRange[P, T]](sr, func(p P) string {
+		return quotePgValue(formatPgPoint(p))
+	}), nil
+}
+
+//Scan方法实现了sql.Scanner接口，将PostgreSQL多范围字面量（比如{[1,3),[5,7)}）转换为区间集合，
+//也接受单个范围字面量。集合中的每一个区间使用区间类型R自身的Scan方法进行转换，所以*R必须实现sql.Scanner接口。
+func (s *RangeSet[P, R]) Scan(src interface{}) error {
+	text, err := pgSourceText(src)
+	if err != nil {
+		return err
+	}
+	literals, err := splitPgMultirange(text)
+	if err != nil {
+		return err
+	}
+	rs := make([]R, 0, len(literals))
+	for _, literal := range literals {
+		var r R
+		scanner, ok := (interface{})(&r).(sql.Scanner)
+		if !ok {
+			return errors.New("ranges: range type does not implement sql.Scanner")
+		}
+		if err := scanner.Scan(literal); err != nil {
+			return err
+		}
+		rs = append(rs, r)
+	}
+	*s = NewRangeSet[P, R](rs...)
+	return nil
+}
+
+//Value方法实现了driver.Valuer接口，将区间集合转换为PostgreSQL多范围字面量，比如，{[1,3),[5,7)}。
+//集合中的每一个区间使用区间类型R自身的Value方法进行转换，所以R必须实现driver.Valuer接口。
+func (s RangeSet[P, R]) Value() (driver.Value, error) {
+	literals := make([]string, 0, len(s.ranges))
+	for _, r := range s.ranges {
+		valuer, ok := (interface{})(r).(driver.Valuer)
+		if !ok {
+			return nil, errors.New("ranges: range type does not implement driver.Valuer")
+		}
+		v, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+		literal, ok := v.(string)
+		if !ok {
+			return nil, errors.New("ranges: range value is not a string")
+		}
+		literals = append(literals, literal)
+	}
+	return "{" + strings.Join(literals, ",") + "}", nil
+}
+
+/////////////////////////////下面是PostgreSQL字面量转换使用的内部函数////////////////////////////////
+
+//pgSourceText函数将数据库驱动返回的数据转换为字符串。
+func pgSourceText(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case nil:
+		return "", errors.New("ranges: cannot scan NULL into a range")
+	default:
+		return "", errors.New("ranges: cannot scan " + v2s(src) + " into a range")
+	}
+}
+
+//scanPgRange函数将数据库驱动返回的PostgreSQL范围字面量转换为类型R的区间。
+func scanPgRange[P any, R any](src interface{}, parsePoint func(string) (P, error)) (R, error) {
+	var proto R
+	text, err := pgSourceText(src)
+	if err != nil {
+		return proto, err
+	}
+	rt, err := parsePgRange(text, parsePoint)
+	if err == nil {
+		err = checkRangeText(typeTo[R, Range[P, R]](proto), rt, text)
+	}
+	if err != nil {
+		return proto, err
+	}
+	return fromRangeText(typeTo[R, Range[P, R]](proto), rt), nil
+}
+
+//parsePgRange函数解析PostgreSQL范围字面量。
+func parsePgRange[P any](s string, parsePoint func(string) (P, error)) (rangeText[P], error) {
+	var result rangeText[P]
+	text := strings.TrimSpace(s)
+	if strings.EqualFold(text, EmptyRangeString) {
+		result.empty = true
+		return result, nil
+	}
+	if len(text) < 3 {
+		return result, &ParseError{Text: s, Msg: "range literal is too short"}
+	}
+	switch text[0] {
+	case '[':
+		result.lower.bound = Closed
+	case '(':
+		result.lower.bound = Open
+	default:
+		return result, &ParseError{Text: s, Msg: "range literal must start with '[' or '('"}
+	}
+	lowerText, lowerQuoted, rest, err := readPgValue(text[1:], ",")
+	if err != nil {
+		return result, &ParseError{Text: s, Msg: err.Error()}
+	}
+	upperText, upperQuoted, rest, err := readPgValue(rest[1:], "])")
+	if err != nil {
+		return result, &ParseError{Text: s, Msg: err.Error()}
+	}
+	if rest[0] == ']' {
+		result.upper.bound = Closed
+	} else {
+		result.upper.bound = Open
+	}
+	if rest[1:] != "" {
+		return result, &ParseError{Text: s, Msg: "unexpected text after range literal"}
+	}
+	//不带双引号的端点值两端的空白字符被忽略
+	if !lowerQuoted {
+		lowerText = strings.TrimSpace(lowerText)
+	}
+	if !upperQuoted {
+		upperText = strings.TrimSpace(upperText)
+	}
+	if !lowerQuoted && lowerText == "" || isNegativeInfinity(lowerText) {
+		result.lower.bound = Unbounded
+	} else if result.lower.value, err = parsePoint(lowerText); err != nil {
+		return result, &ParseError{Text: s, Msg: "invalid lower bound", Err: err}
+	}
+	if !upperQuoted && upperText == "" || isPositiveInfinity(upperText) {
+		result.upper.bound = Unbounded
+	} else if result.upper.value, err = parsePoint(upperText); err != nil {
+		return result, &ParseError{Text: s, Msg: "invalid upper bound", Err: err}
+	}
+	return result, nil
+}
+
+//readPgValue函数从文本s的开头读取一个端点的值，直到遇到不在双引号之内、也没有被转义的终止字符（terminators）为止，
+//返回端点的值、值是否带有双引号，以及从终止字符开始的剩余文本。
+func readPgValue(s string, terminators string) (value string, quoted bool, rest string, err error) {
+	var sb strings.Builder
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(s) {
+				return "", false, "", errors.New("unexpected end of range literal after '\\'")
+			}
+			i++
+			sb.WriteByte(s[i])
+		case c == '"':
+			if inQuotes && i+1 < len(s) && s[i+1] == '"' {
+				i++
+				sb.WriteByte('"')
+				continue
+			}
+			inQuotes = !inQuotes
+			quoted = true
+		case !inQuotes && strings.IndexByte(terminators, c) >= 0:
+			return sb.String(), quoted, s[i:], nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", false, "", errors.New("missing " + strings.Join(strings.Split(terminators, ""), " or ") + " in range literal")
+}
+
+//splitPgMultirange函数将PostgreSQL多范围字面量拆分为若干范围字面量。如果文本不是以“{”开始，
+//则将其作为单个范围字面量。
+func splitPgMultirange(s string) ([]string, error) {
+	text := strings.TrimSpace(s)
+	if !strings.HasPrefix(text, "{") {
+		return []string{text}, nil
+	}
+	if !strings.HasSuffix(text, "}") {
+		return nil, &ParseError{Text: s, Msg: "multirange literal must end with '}'"}
+	}
+	var literals []string
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	for inner != "" {
+		if strings.HasPrefix(strings.ToLower(inner), EmptyRangeString) {
+			inner = inner[len(EmptyRangeString):]
+		} else {
+			_, _, rest, err := readPgValue(inner, ",")
+			if err != nil {
+				return nil, &ParseError{Text: s, Msg: err.Error()}
+			}
+			_, _, rest, err = readPgValue(rest[1:], "])")
+			if err != nil {
+				return nil, &ParseError{Text: s, Msg: err.Error()}
+			}
+			end := len(inner) - len(rest) + 1
+			literals = append(literals, inner[:end])
+			inner = inner[end:]
+		}
+		inner = strings.TrimSpace(inner)
+		if inner == "" {
+			break
+		}
+		if inner[0] != ',' {
+			return nil, &ParseError{Text: s, Msg: "missing ',' between ranges"}
+		}
+		inner = strings.TrimSpace(inner[1:])
+	}
+	return literals, nil
+}
+
+//formatPgRange函数将区间r转换为PostgreSQL范围字面量，其中，f函数负责将端点的值转换为字面量中的文本。
+func formatPgRange[P any, R any](r Range[P, R], f func(P) string) string {
+	if IsEmpty(r) {
+		return EmptyRangeString
+	}
+	start, end := r.DeRange()
+	left, right := r.Bounds()
+	brackets := boundsToBrackets(left, right)
+	var startText, endText string
+	if left != Unbounded {
+		startText = f(start)
+	}
+	if right != Unbounded {
+		endText = f(end)
+	}
+	return brackets[:1] + startText + "," + endText + brackets[1:]
+}
+
+//quotePgValue函数用双引号括起端点的值，并转义其中的双引号与反斜杠。
+func quotePgValue(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+//parsePgPoint函数解析PostgreSQL范围字面量中端点的值，time.Time类型的端点依次尝试pgTimeLayouts中的格式，
+//其他类型的端点由textToPoint函数解析。
+func parsePgPoint[P any](text string) (P, error) {
+	var p P
+	if _, ok := (interface{})(p).(time.Time); !ok {
+		return textToPoint[P](text)
+	}
+	var err error
+	for _, layout := range pgTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, text); err == nil {
+			return typeTo[time.Time, P](t), nil
+		}
+	}
+	return p, err
+}
+
+//formatPgPoint函数将端点的值转换为PostgreSQL范围字面量中的文本，time.Time类型的端点使用PG_TIME_LAYOUT格式，
+//其他类型的端点如果实现了encoding.TextMarshaler接口，则使用该接口进行转换。
+func formatPgPoint[P any](p P) string {
+	switch v := (interface{})(p).(type) {
+	case time.Time:
+		return v.Format(PG_TIME_LAYOUT)
+	case encoding.TextMarshaler:
+		if text, err := v.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return v2s(p)
+}
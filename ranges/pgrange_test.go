@@ -0,0 +1,94 @@
+package ranges
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPgRange(t *testing.T) {
+	numbers := map[string]string{
+		"[1,5)":         "[1,5)",
+		" ( 1 , 5 ] ":   "(1,5]",
+		"[1,)":          "[1,+∞)",
+		"(,5]":          "(-∞,5]",
+		"(,)":           "(-∞,+∞)",
+		"empty":         "empty",
+		`["1","5"]`:     "[1,5]",
+		`(-infinity,5)`: "(-∞,5)",
+		`[1.5,2.25)`:    "[1.5,2.25)",
+		`["1\.5",2.25)`: "[1.5,2.25)",
+		`["1"",5)`:      "",
+		`[1,5`:          "",
+		`{1,5}`:         "",
+		`[1,5)x`:        "",
+		`[5,1)`:         "",
+	}
+	for literal, want := range numbers {
+		var r NumberRange[float64]
+		err := r.Scan([]byte(literal))
+		if want == "" {
+			if err == nil {
+				t.Errorf("Scan(%q) should fail, got %s", literal, r.String())
+			}
+			continue
+		}
+		if err != nil || r.String() != want {
+			t.Errorf("Scan(%q): got %s, %v", literal, r.String(), err)
+		}
+	}
+	for _, r := range []NumberRange[int]{CreateNumberRange(1, 5), CreateNumberRangeTo(5, Closed), {}} {
+		v, _ := r.Value()
+		var back NumberRange[int]
+		if err := back.Scan(v); err != nil || !back.Equal(r) {
+			t.Errorf("Value/Scan of %s: got %v and %s, %v", r.String(), v, back.String(), err)
+		}
+	}
+	var null NumberRange[int]
+	if err := null.Scan(nil); err == nil {
+		t.Errorf("Scan(nil) should fail")
+	}
+
+	tz := time.FixedZone("", 5*3600+1800)
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := map[string]TimeInterval{
+		`["2022-01-01 00:00:00+00","2022-01-02 00:00:00+00")`: CreateTimeInterval(day, day.Add(24*time.Hour)),
+		`["2022-01-01 05:30:00.5+05:30",infinity]`:            CreateTimeIntervalFrom(time.Date(2022, 1, 1, 5, 30, 0, 5e8, tz), Closed),
+		`("2022-01-01 00:00:00","2022-01-01 12:00:00"]`:       CreateTimeIntervalWithBounds(day, day.Add(12*time.Hour), Open, Closed),
+		`[2022-01-01,2022-01-03)`:                             CreateTimeInterval(day, day.Add(48*time.Hour)),
+		`(,"2022-01-01T00:00:00Z")`:                           CreateTimeIntervalTo(day, Open),
+	}
+	for literal, want := range times {
+		var ti TimeInterval
+		if err := ti.Scan(literal); err != nil || !ti.Equal(want) {
+			t.Errorf("Scan(%q): got %s, %v", literal, Tintvl2Str(ti), err)
+		}
+	}
+	ti := CreateTimeInterval(day, day.Add(90*time.Minute))
+	v, _ := ti.Value()
+	if v != `["2022-01-01 00:00:00Z","2022-01-01 01:30:00Z")` {
+		t.Errorf("Value: got %v", v)
+	}
+	var back TimeInterval
+	if err := back.Scan(v); err != nil || !back.Equal(ti) {
+		t.Errorf("Scan(%v): got %s, %v", v, Tintvl2Str(back), err)
+	}
+
+	var set RangeSet[int, NumberRange[int]]
+	if err := set.Scan(`{[1,3), [5,7) ,empty,[7,9]}`); err != nil || set.String() != "{[1,3),[5,10)}" {
+		t.Errorf("Scan multirange: got %s, %v", set.String(), err)
+	}
+	if v, err := set.Value(); err != nil || v != "{[1,3),[5,10)}" {
+		t.Errorf("Value multirange: got %v, %v", v, err)
+	}
+	if err := set.Scan(`{}`); err != nil || !set.IsEmpty() {
+		t.Errorf("Scan empty multirange: got %s, %v", set.String(), err)
+	}
+	if err := set.Scan(`{[1,3) [5,7)}`); err == nil {
+		t.Errorf("Scan of malformed multirange should fail")
+	}
+	var tset RangeSet[time.Time, TimeInterval]
+	if err := tset.Scan(`{["2022-01-01 00:00:00+00","2022-01-01 01:00:00+00"),["2022-01-01 01:00:00+00",)}`); err != nil ||
+		tset.Len() != 1 || !tset.Contains(day.AddDate(1, 0, 0)) {
+		t.Errorf("Scan tstzmultirange: got %s, %v", tset.String(), err)
+	}
+}
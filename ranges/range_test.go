@@ -80,3 +80,92 @@ func TestSequenceRange(t *testing.T) {
 	println(Tintvl2Str(ti2), "+", Tintvl2Str(ti3), "=", Tintvl2Str(resultTi), yes)
 
 }
+
+func TestEmptyRange(t *testing.T) {
+	var zero NumberRange[int]
+	point := CreateNumberRangeWithBounds(0, 0, Closed, Closed)
+	if !zero.IsEmpty() || zero.IsPoint() || point.IsEmpty() || !point.IsPoint() || zero.Equal(point) {
+		t.Errorf("empty range [0,0) is not distinguished from point [0,0]")
+	}
+	if zero.String() != "empty" || point.String() != "[0,1)" {
+		t.Errorf("String: got %s and %s", zero.String(), point.String())
+	}
+	ok, r := CreateNumberRange(5, 8).Intersect(CreateNumberRange(10, 12))
+	if ok || !r.IsEmpty() || !r.Equal(CreateNumberRangeWithBounds(3, 3, Open, Open)) {
+		t.Errorf("Intersect of disjoint ranges: got %s, %v", r.String(), ok)
+	}
+	if ok, r := CreateNumberRange(1, 6).IntersectOthers([]NumberRange[int]{CreateNumberRange(0, 3), CreateNumberRange(3, 5)}); !ok || !r.IsEmpty() {
+		t.Errorf("IntersectOthers: got %s, %v", r.String(), ok)
+	}
+	if ok, r := CreateNumberRange(1, 6).IntersectOthers([]NumberRange[int]{CreateNumberRange(7, 9), CreateNumberRange(0, 3)}); ok || !r.IsEmpty() {
+		t.Errorf("IntersectOthers of disjoint ranges: got %s, %v", r.String(), ok)
+	}
+	if ok, r := CreateNumberRange(1, 6).IntersectOthers([]NumberRange[int]{CreateNumberRange(0, 5), CreateNumberRange(3, 8)}); !ok || r.String() != "[3,5)" {
+		t.Errorf("IntersectOthers: got %s, %v", r.String(), ok)
+	}
+	if ok, r := zero.Union(CreateNumberRange(5, 8)); !ok || r.String() != "[5,8)" {
+		t.Errorf("empty+[5,8): got %s, %v", r.String(), ok)
+	}
+	if r1, r2 := CreateNumberRange(1, 3).Except(CreateNumberRange(0, 5)); !r1.IsEmpty() || !r2.IsEmpty() {
+		t.Errorf("[1,3)-[0,5): got %s and %s", r1.String(), r2.String())
+	}
+	if r1, r2 := zero.Except(CreateNumberRange(5, 8)); !r1.IsEmpty() || !r2.IsEmpty() {
+		t.Errorf("empty-[5,8): got %s and %s", r1.String(), r2.String())
+	}
+	if CreateNumberRange(5, 8).IsIntersected(point) || !CreateNumberRange(-1, 1).IsIntersected(point) ||
+		CreateNumberRange(-1, 1).IsIntersected(zero) {
+		t.Errorf("IsIntersected with empty range")
+	}
+	var ti TimeInterval
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !ti.IsEmpty() || Tintvl2Str(ti) != "empty" {
+		t.Errorf("zero TimeInterval is not empty")
+	}
+	if ok, r := CreateTimeInterval(day, day.Add(time.Hour)).Intersect(CreateTimeInterval(day.Add(2*time.Hour), day.Add(3*time.Hour))); ok || !r.IsEmpty() {
+		t.Errorf("Intersect of disjoint time intervals: got %s, %v", Tintvl2Str(r), ok)
+	}
+}
+
+func TestExceptOthers(t *testing.T) {
+	nr := CreateNumberRangeWithBounds[int]
+	this := CreateNumberRange(0, 10)
+	others := []NumberRange[int]{
+		CreateNumberRange(2, 3), nr(7, 8, Closed, Closed), CreateNumberRange(4, 4), nr(1, 2, Closed, Closed), nr(9, 12, Open, Open),
+	}
+	if got := NewRangeSet[int, NumberRange[int]](this.ExceptOthers(others)...).String(); got != "{[0,1),[3,7),[9,10)}" {
+		t.Errorf("ExceptOthers: got %s", got)
+	}
+	if others[0].String() != "[2,3)" {
+		t.Errorf("ExceptOthers should not modify others")
+	}
+	if got := this.ExceptOthers(nil); len(got) != 1 || !got[0].Equal(this) {
+		t.Errorf("ExceptOthers(nil) = %v", got)
+	}
+	if got := this.ExceptOthers([]NumberRange[int]{nr(0, 5, Unbounded, Closed), CreateNumberRange(3, 20)}); got != nil {
+		t.Errorf("ExceptOthers covering the range = %v", got)
+	}
+	if got := CreateNumberRange(3, 3).ExceptOthers(others); got != nil {
+		t.Errorf("ExceptOthers of an empty range = %v", got)
+	}
+	ir := CreateIntRangeWithBounds[int]
+	if got := ir(1, 10, Closed, Closed).ExceptOthers([]IntRange[int]{ir(3, 5, Closed, Closed), ir(8, 8, Closed, Closed)}); len(got) != 3 ||
+		got[0].String() != "[1,3)" || got[1].String() != "[6,8)" || got[2].String() != "[9,11)" {
+		t.Errorf("IntRange ExceptOthers: got %v", got)
+	}
+
+	//维护窗口把可用时段切分为多段
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	available := CreateTimeInterval(at(8), at(20))
+	windows := []TimeInterval{CreateTimeInterval(at(15), at(16)), CreateTimeInterval(at(6), at(9)), CreateTimeInterval(at(12), at(13)), CreateTimeInterval(at(12), at(14))}
+	got := available.ExceptOthers(windows)
+	want := []TimeInterval{CreateTimeInterval(at(9), at(12)), CreateTimeInterval(at(14), at(15)), CreateTimeInterval(at(16), at(20))}
+	if len(got) != len(want) {
+		t.Fatalf("TimeInterval ExceptOthers: got %d intervals", len(got))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("TimeInterval ExceptOthers: the %dth interval is %s", i, Tintvl2Str(got[i]))
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package ranges
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//rangeJSON定义了区间的JSON对象形式，比如，{"start":1,"end":5,"bounds":"[]"}。
+//其中，bounds是起点与终点的括号，缺省为"[)"，无界的端点不出现在JSON对象中，
+//比如，{"start":"2022-01-01T00:00:00Z"}表示[2022-01-01T00:00:00Z,+∞)，而空区间表示为{"empty":true}。
+type rangeJSON struct {
+	Start  json.RawMessage `json:"start,omitempty"`
+	End    json.RawMessage `json:"end,omitempty"`
+	Bounds string          `json:"bounds,omitempty"`
+	Empty  bool            `json:"empty,omitempty"`
+}
+
+//marshalRangeJSON函数将区间r编码为JSON对象，端点的值使用类型P自身的JSON编码，
+//因而，time.Time类型的端点被编码为RFC 3339格式的字符串。
+func marshalRangeJSON[P any, R any](r Range[P, R]) ([]byte, error) {
+	var rj rangeJSON
+	if IsEmpty(r) {
+		rj.Empty = true
+		return json.Marshal(rj)
+	}
+	start, end := r.DeRange()
+	left, right := r.Bounds()
+	var err error
+	if left != Unbounded {
+		if rj.Start, err = json.Marshal(start); err != nil {
+			return nil, err
+		}
+	}
+	if right != Unbounded {
+		if rj.End, err = json.Marshal(end); err != nil {
+			return nil, err
+		}
+	}
+	if brackets := boundsToBrackets(left, right); brackets != "[)" {
+		rj.Bounds = brackets
+	}
+	return json.Marshal(rj)
+}
+
+//unmarshalRangeJSON函数借助区间proto，将JSON数据解码为区间，JSON数据可以是对象形式，
+//比如，{"start":1,"end":5}，也可以是字符串形式，比如，"[1,5)"。
+func unmarshalRangeJSON[P any, R any](proto Range[P, R], data []byte) (R, error) {
+	return unmarshalRangeJSONWith(proto, data, textToPoint[P])
+}
+
+//unmarshalRangeJSONWith函数与unmarshalRangeJSON相同，但是使用parsePoint函数解析字符串形式中端点的文本。
+func unmarshalRangeJSONWith[P any, R any](proto Range[P, R], data []byte, parsePoint func(string) (P, error)) (R, error) {
+	var result R
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return result, err
+		}
+		rt, err := parseRangeText(s, parsePoint)
+		if err == nil {
+			err = checkRangeText(proto, rt, s)
+		}
+		if err != nil {
+			return result, err
+		}
+		return fromRangeText(proto, rt), nil
+	}
+	var rj rangeJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return result, err
+	}
+	var rt rangeText[P]
+	if rj.Empty {
+		rt.empty = true
+		return fromRangeText(proto, rt), nil
+	}
+	left, right, err := bracketsToBounds(rj.Bounds)
+	if err != nil {
+		return result, err
+	}
+	rt.lower.bound, rt.upper.bound = left, right
+	if isJSONAbsent(rj.Start) {
+		rt.lower.bound = Unbounded
+	} else if err = json.Unmarshal(rj.Start, &rt.lower.value); err != nil {
+		return result, err
+	}
+	if isJSONAbsent(rj.End) {
+		rt.upper.bound = Unbounded
+	} else if err = json.Unmarshal(rj.End, &rt.upper.value); err != nil {
+		return result, err
+	}
+	if err = checkRangeText(proto, rt, string(data)); err != nil {
+		return result, err
+	}
+	return fromRangeText(proto, rt), nil
+}
+
+//checkRangeText函数借助区间proto检查解析得到的结果rt，起点在终点之后时返回*ParseError，
+//而不是交换起点与终点，比如，{"start":5,"end":1}是错误的，而不是区间(1,5]。text是被解析的文本。
+func checkRangeText[P any, R any](proto Range[P, R], rt rangeText[P], text string) error {
+	if rt.empty || rt.lower.bound == Unbounded || rt.upper.bound == Unbounded {
+		return nil
+	}
+	if comparePoints(proto, rt.lower.value, rt.upper.value) > 0 {
+		return &ParseError{Text: text, Msg: "start is after end"}
+	}
+	return nil
+}
+
+//fromRangeText函数借助区间proto，用解析得到的结果rt创建区间。
+func fromRangeText[P any, R any](proto Range[P, R], rt rangeText[P]) R {
+	if rt.empty {
+		return emptyOf(proto)
+	}
+	return withEndpoints(proto, rt.lower, rt.upper)
+}
+
+//isJSONAbsent函数判断JSON对象中的字段是否不存在或者为null。
+func isJSONAbsent(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+//textToPoint函数将端点的文本转换为类型P的值。
+//如果*P实现了encoding.TextUnmarshaler接口（比如time.Time，使用RFC 3339格式），则使用该接口进行转换，
+//否则，将文本作为JSON值（比如数字）进行转换，如果仍然失败，则将文本作为JSON字符串进行转换。
+//P是字符串类型时，带引号的文本首先按照Go的字符串字面量（strconv.Quote的输出）进行转换，
+//以便还原OrderedRange化为字符串时用“\xff”等形式转义的非UTF-8字节。
+func textToPoint[P any](s string) (P, error) {
+	var p P
+	if tu, ok := (interface{})(&p).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(s))
+		return p, err
+	}
+	if v := reflect.ValueOf(&p).Elem(); v.Kind() == reflect.String && strings.HasPrefix(s, `"`) {
+		if u, err := strconv.Unquote(s); err == nil {
+			v.SetString(u)
+			return p, nil
+		}
+	}
+	err := json.Unmarshal([]byte(s), &p)
+	//以引号开始的文本必须是完整的JSON字符串，以便正确解析含有逗号的字符串端点，比如，["a,b","c"]
+	if err != nil && !strings.HasPrefix(s, `"`) && json.Unmarshal([]byte(strconv.Quote(s)), &p) == nil {
+		err = nil
+	}
+	return p, err
+}
+
+//boundsToBrackets函数将起点与终点的边界类型转换为两个括号，比如"[)"。无界端点的括号是开括号。
+func boundsToBrackets(left, right BoundType) string {
+	brackets := []byte("[)")
+	if left != Closed {
+		brackets[0] = '('
+	}
+	if right == Closed {
+		brackets[1] = ']'
+	}
+	return string(brackets)
+}
+
+//bracketsToBounds函数将两个括号（比如"[)"）转换为起点与终点的边界类型，空字符串表示缺省的"[)"。
+func bracketsToBounds(brackets string) (left, right BoundType, err error) {
+	left, right = Closed, Open
+	if brackets == "" {
+		return
+	}
+	if len(brackets) != 2 {
+		err = errors.New("ranges: invalid bounds " + strconv.Quote(brackets))
+		return
+	}
+	switch brackets[0] {
+	case '[':
+	case '(':
+		left = Open
+	default:
+		err = errors.New("ranges: invalid bounds " + strconv.Quote(brackets))
+	}
+	switch brackets[1] {
+	case ']':
+		right = Closed
+	case ')':
+	default:
+		err = errors.New("ranges: invalid bounds " + strconv.Quote(brackets))
+	}
+	return
+}
@@ -0,0 +1,77 @@
+package ranges
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRangeJSON(t *testing.T) {
+	marshals := []struct {
+		r    NumberRange[float64]
+		want string
+	}{
+		{CreateNumberRange(1.5, 5), `{"start":1.5,"end":5}`},
+		{CreateNumberRangeWithBounds(1.5, 5, Open, Closed), `{"start":1.5,"end":5,"bounds":"(]"}`},
+		{CreateNumberRangeTo(100.0, Open), `{"end":100,"bounds":"()"}`},
+		{NumberRange[float64]{}, `{"empty":true}`},
+	}
+	for _, c := range marshals {
+		data, err := json.Marshal(c.r)
+		if err != nil || string(data) != c.want {
+			t.Errorf("Marshal(%s): got %s, %v", c.r.String(), data, err)
+		}
+		var back NumberRange[float64]
+		if err := json.Unmarshal(data, &back); err != nil || !back.Equal(c.r) {
+			t.Errorf("Unmarshal(%s): got %s, %v", data, back.String(), err)
+		}
+	}
+	unmarshals := map[string]string{
+		`"[1,5)"`:                   "[1,5)",
+		`" ( 1 , 5 ] "`:             "[2,6)",
+		`"[3,+∞)"`:                  "[3,+∞)",
+		`"empty"`:                   "empty",
+		`{"start":1,"end":1}`:       "empty",
+		`{"start":null,"end":5}`:    "(-∞,5)",
+		`{"start":1,"bounds":"[]"}`: "[1,+∞)",
+	}
+	for data, want := range unmarshals {
+		var r NumberRange[int]
+		if err := json.Unmarshal([]byte(data), &r); err != nil || r.String() != want {
+			t.Errorf("Unmarshal(%s): got %s, %v", data, r.String(), err)
+		}
+	}
+	for _, data := range []string{`"[1,5"`, `"[1;5)"`, `{"start":1,"end":5,"bounds":"<>"}`, `{"start":"a"}`, `"[1.5,2)"`, `"[5,1)"`} {
+		var r NumberRange[int]
+		if err := json.Unmarshal([]byte(data), &r); err == nil {
+			t.Errorf("Unmarshal(%s) should fail, got %s", data, r.String())
+		}
+	}
+	var reversed NumberRange[int]
+	var pe *ParseError
+	if err := json.Unmarshal([]byte(`{"start":5,"end":1}`), &reversed); !errors.As(err, &pe) {
+		t.Errorf("Unmarshal of reversed endpoints should fail with ParseError, got %s, %v", reversed.String(), err)
+	}
+
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var config struct {
+		Period   TimeInterval `json:"period"`
+		Contract TimeInterval `json:"contract"`
+	}
+	config.Period = CreateTimeInterval(day, day.Add(24*time.Hour))
+	config.Contract = CreateTimeIntervalFrom(day, Closed)
+	data, err := json.Marshal(config)
+	want := `{"period":{"start":"2022-01-01T00:00:00Z","end":"2022-01-02T00:00:00Z"},"contract":{"start":"2022-01-01T00:00:00Z"}}`
+	if err != nil || string(data) != want {
+		t.Errorf("Marshal: got %s, %v", data, err)
+	}
+	config.Period, config.Contract = TimeInterval{}, TimeInterval{}
+	data = []byte(`{"period":"[2022-01-01T00:00:00Z,2022-01-02T00:00:00Z)","contract":{"start":"2022-01-01T00:00:00Z"}}`)
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !config.Period.Equal(CreateTimeInterval(day, day.Add(24*time.Hour))) || !config.Contract.Equal(CreateTimeIntervalFrom(day, Closed)) {
+		t.Errorf("Unmarshal: got %s and %s", Tintvl2Str(config.Period), Tintvl2Str(config.Contract))
+	}
+}
@@ -0,0 +1,179 @@
+package ranges
+
+import (
+	"sort"
+	"strings"
+)
+
+//RangeMap[P,R,V]定义了从互不相交的区间到数据的映射，类似于Guava的RangeMap，适用于电价表、价格表等按区间定价的场景。
+//Put方法放入新的区间时，新区间覆盖已有区间中与之相交的部分，已有区间被拆分，只保留不相交的部分，拆分的方法与Except函数相同，
+//比如，在{[1,10)→a}中放入[3,5)→b，结果是{[1,3)→a,[3,5)→b,[5,10)→a}。
+//使用NewCoalescingRangeMap函数构造的映射，还会把相邻并且数据相等的条目合并为一个条目。
+//映射中的条目按照区间起点的先后顺序保存在切片中，Get方法的时间复杂度是O(log n)，Put与Remove方法的时间复杂度是O(n)。
+//RangeMap不是线程安全类型，请注意不要在多线程环境下使用。
+type RangeMap[P any, R any, V any] struct {
+	entries []IntervalEntry[R, V] //有序、互不相交的条目
+	equal   func(a, b V) bool     //判断数据是否相等的函数，nil表示不合并相邻的条目
+}
+
+//NewRangeMap函数构造一个空的区间映射，并返回其指针。
+func NewRangeMap[P any, R any, V any]() *RangeMap[P, R, V] {
+	return &RangeMap[P, R, V]{}
+}
+
+//NewCoalescingRangeMap函数构造一个空的区间映射，并返回其指针。该映射使用函数equal判断数据是否相等，
+//放入或删除区间之后，相邻（相交是不可能的）并且数据相等的条目会被合并为一个条目。
+func NewCoalescingRangeMap[P any, R any, V any](equal func(a, b V) bool) *RangeMap[P, R, V] {
+	return &RangeMap[P, R, V]{equal: equal}
+}
+
+//Len方法返回映射中条目的个数。
+func (m *RangeMap[P, R, V]) Len() int {
+	return len(m.entries)
+}
+
+//Put方法将区间r映射到数据v，r覆盖映射中已有区间与之相交的部分。r是空区间时，映射不变。
+func (m *RangeMap[P, R, V]) Put(r R, v V) {
+	rr := typeTo[R, Range[P, R]](r)
+	if IsEmpty(rr) {
+		return
+	}
+	i, j := m.affected(rr)
+	m.splice(rr, i, j, []IntervalEntry[R, V]{{Range: r, Value: v}})
+}
+
+//Remove方法从映射中去掉区间r，已有区间与r相交的部分被删除，不相交的部分仍然保留。
+func (m *RangeMap[P, R, V]) Remove(r R) {
+	rr := typeTo[R, Range[P, R]](r)
+	if IsEmpty(rr) {
+		return
+	}
+	i, j := m.affected(rr)
+	m.splice(rr, i, j, nil)
+}
+
+//Get方法返回点p所映射的数据，如果没有区间包含点p，返回false。
+func (m *RangeMap[P, R, V]) Get(p P) (V, bool) {
+	e, ok := m.GetEntry(p)
+	return e.Value, ok
+}
+
+//GetEntry方法返回包含点p的条目，如果没有区间包含点p，返回false。
+func (m *RangeMap[P, R, V]) GetEntry(p P) (IntervalEntry[R, V], bool) {
+	lower := endpoint[P]{p, Closed}
+	i := sort.Search(len(m.entries), func(i int) bool {
+		r := typeTo[R, Range[P, R]](m.entries[i].Range)
+		rLower, _ := endpoints(r)
+		return compareLower(r, rLower, lower) > 0
+	}) - 1
+	if i >= 0 && typeTo[R, Range[P, R]](m.entries[i].Range).IsIncludedPoint(p) {
+		return m.entries[i], true
+	}
+	return IntervalEntry[R, V]{}, false
+}
+
+//SubMap方法返回映射在区间r之内的部分，条目的区间是原有区间与r的交集。返回的映射是一个新的映射，
+//修改它不会影响原有的映射，二者判断数据是否相等的方式相同。
+func (m *RangeMap[P, R, V]) SubMap(r R) *RangeMap[P, R, V] {
+	result := &RangeMap[P, R, V]{equal: m.equal}
+	rr := typeTo[R, Range[P, R]](r)
+	if IsEmpty(rr) {
+		return result
+	}
+	i, j := m.affected(rr)
+	for _, e := range m.entries[i:j] {
+		if _, piece := Intersect(typeTo[R, Range[P, R]](e.Range), rr); !IsEmpty(typeTo[R, Range[P, R]](piece)) {
+			result.entries = append(result.entries, IntervalEntry[R, V]{Range: piece, Value: e.Value})
+		}
+	}
+	return result
+}
+
+//Entries方法按照区间起点的先后顺序返回映射中的所有条目，返回的切片是一个副本，修改它不会影响映射本身。
+func (m *RangeMap[P, R, V]) Entries() []IntervalEntry[R, V] {
+	result := make([]IntervalEntry[R, V], len(m.entries))
+	copy(result, m.entries)
+	return result
+}
+
+//Walk方法按照区间起点的先后顺序，依次使用函数f访问映射中的所有条目，f返回false时停止访问。
+func (m *RangeMap[P, R, V]) Walk(f func(e IntervalEntry[R, V]) bool) {
+	for _, e := range m.entries {
+		if !f(e) {
+			return
+		}
+	}
+}
+
+//String方法将映射化为字符串，格式为{[start1,end1)→value1,[start2,end2)→value2}。
+func (m *RangeMap[P, R, V]) String() string {
+	strs := make([]string, 0, len(m.entries))
+	for _, e := range m.entries {
+		strs = append(strs, typeTo[R, Range[P, R]](e.Range).String()+"→"+v2s(e.Value))
+	}
+	return "{" + strings.Join(strs, ",") + "}"
+}
+
+//affected方法使用二分查找，返回与区间r相交的条目的下标范围[i,j)。
+func (m *RangeMap[P, R, V]) affected(r Range[P, R]) (i, j int) {
+	i = sort.Search(len(m.entries), func(k int) bool {
+		return !IsBefore(typeTo[R, Range[P, R]](m.entries[k].Range), r)
+	})
+	j = i + sort.Search(len(m.entries)-i, func(k int) bool {
+		return IsBefore(r, typeTo[R, Range[P, R]](m.entries[i+k].Range))
+	})
+	return
+}
+
+//splice方法用下标范围[i,j)中的条目去掉区间r之后的剩余部分，以及新的条目（inserted），替换下标范围[i,j)中的条目，
+//然后合并新条目附近相邻并且数据相等的条目。
+//由于条目互不相交，只有第一个条目可能在r之前有剩余部分，只有最后一个条目可能在r之后有剩余部分。
+func (m *RangeMap[P, R, V]) splice(r Range[P, R], i, j int, inserted []IntervalEntry[R, V]) {
+	var before, after []IntervalEntry[R, V]
+	for _, e := range m.entries[i:j] {
+		r1, r2 := Except(typeTo[R, Range[P, R]](e.Range), r)
+		for _, piece := range []R{r1, r2} {
+			p := typeTo[R, Range[P, R]](piece)
+			switch {
+			case IsEmpty(p):
+			case IsBefore(p, r):
+				before = append(before, IntervalEntry[R, V]{Range: piece, Value: e.Value})
+			default:
+				after = append(after, IntervalEntry[R, V]{Range: piece, Value: e.Value})
+			}
+		}
+	}
+	replacement := make([]IntervalEntry[R, V], 0, len(before)+len(inserted)+len(after))
+	replacement = append(append(append(replacement, before...), inserted...), after...)
+	tail := append(replacement, m.entries[j:]...)
+	m.entries = append(m.entries[:i], tail...)
+	if m.equal != nil {
+		m.coalesce(i-1, i+len(replacement)+1)
+	}
+}
+
+//coalesce方法合并下标范围[from,to)之内相邻并且数据相等的条目，下标范围超出切片的部分会被忽略。
+func (m *RangeMap[P, R, V]) coalesce(from, to int) {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(m.entries) {
+		to = len(m.entries)
+	}
+	if to-from < 2 {
+		return
+	}
+	merged := []IntervalEntry[R, V]{m.entries[from]}
+	for _, e := range m.entries[from+1 : to] {
+		last := &merged[len(merged)-1]
+		if m.equal(last.Value, e.Value) {
+			if isSuccessive, union := Union(typeTo[R, Range[P, R]](last.Range), typeTo[R, Range[P, R]](e.Range)); isSuccessive {
+				last.Range = union
+				continue
+			}
+		}
+		merged = append(merged, e)
+	}
+	tail := append(merged, m.entries[to:]...)
+	m.entries = append(m.entries[:from], tail...)
+}
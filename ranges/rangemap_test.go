@@ -0,0 +1,86 @@
+package ranges
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRangeMap(t *testing.T) {
+	m := NewRangeMap[int, NumberRange[int], string]()
+	m.Put(CreateNumberRange(1, 10), "a")
+	m.Put(CreateNumberRange(3, 5), "b")
+	if s := m.String(); s != "{[1,3)→a,[3,5)→b,[5,10)→a}" {
+		t.Errorf("Put: got %s", s)
+	}
+	m.Put(CreateNumberRangeWithBounds(4, 12, Closed, Closed), "c")
+	m.Put(CreateNumberRange(0, 0), "empty")
+	if s := m.String(); s != "{[1,3)→a,[3,4)→b,[4,13)→c}" {
+		t.Errorf("Put: got %s", s)
+	}
+	for p, want := range map[int]string{1: "a", 3: "b", 4: "c", 12: "c", 13: "", 0: ""} {
+		if v, ok := m.Get(p); v != want || ok != (want != "") {
+			t.Errorf("Get(%d) = %s, %v", p, v, ok)
+		}
+	}
+	if e, ok := m.GetEntry(7); !ok || e.Range.String() != "[4,13)" {
+		t.Errorf("GetEntry(7) = %v, %v", e, ok)
+	}
+	sub := m.SubMap(CreateNumberRange(2, 5))
+	if s := sub.String(); s != "{[2,3)→a,[3,4)→b,[4,5)→c}" {
+		t.Errorf("SubMap: got %s", s)
+	}
+	sub.Remove(CreateNumberRange(0, 100))
+	if sub.Len() != 0 || m.Len() != 3 {
+		t.Errorf("SubMap should be independent, got %s and %s", sub.String(), m.String())
+	}
+	m.Remove(CreateNumberRange(2, 6))
+	if s := m.String(); s != "{[1,2)→a,[6,13)→c}" {
+		t.Errorf("Remove: got %s", s)
+	}
+	var visited []string
+	m.Walk(func(e IntervalEntry[NumberRange[int], string]) bool {
+		visited = append(visited, e.Value)
+		return false
+	})
+	if len(visited) != 1 || visited[0] != "a" || len(m.Entries()) != 2 {
+		t.Errorf("Walk: got %v", visited)
+	}
+}
+
+func TestCoalescingRangeMap(t *testing.T) {
+	m := NewCoalescingRangeMap[int, NumberRange[int], string](func(a, b string) bool { return a == b })
+	m.Put(CreateNumberRange(1, 10), "a")
+	m.Put(CreateNumberRange(3, 5), "a")
+	if s := m.String(); s != "{[1,10)→a}" {
+		t.Errorf("Put: got %s", s)
+	}
+	m.Put(CreateNumberRange(10, 12), "a")
+	m.Put(CreateNumberRange(13, 15), "a")
+	m.Put(CreateNumberRange(0, 1), "b")
+	if s := m.String(); s != "{[0,1)→b,[1,12)→a,[13,15)→a}" {
+		t.Errorf("Put: got %s", s)
+	}
+	m.Put(CreateNumberRange(5, 7), "b")
+	m.Put(CreateNumberRange(5, 7), "a")
+	m.Put(CreateNumberRange(12, 13), "a")
+	if s := m.String(); s != "{[0,1)→b,[1,15)→a}" {
+		t.Errorf("Put: got %s", s)
+	}
+
+	//分时电价：峰时覆盖平时
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	hours := func(from, to int) TimeInterval {
+		return CreateTimeInterval(day.Add(time.Duration(from)*time.Hour), day.Add(time.Duration(to)*time.Hour))
+	}
+	tariff := NewCoalescingRangeMap[time.Time, TimeInterval, float64](func(a, b float64) bool { return a == b })
+	tariff.Put(hours(0, 24), 0.5)
+	tariff.Put(hours(8, 12), 1.2)
+	tariff.Put(hours(18, 22), 1.2)
+	tariff.Put(hours(12, 18), 1.2)
+	if tariff.Len() != 3 {
+		t.Errorf("tariff: got %s", tariff.String())
+	}
+	if price, ok := tariff.Get(day.Add(15 * time.Hour)); !ok || price != 1.2 {
+		t.Errorf("Get: got %v, %v", price, ok)
+	}
+}
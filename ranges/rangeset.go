@@ -0,0 +1,235 @@
+package ranges
+
+import (
+	"sort"
+	"strings"
+)
+
+//RangeSet[P,R]定义了由若干互不相交、互不相邻的区间构成的区间集合。
+//集合内部的区间按照起点的先后顺序排列，任何相交或相邻的区间在加入集合时都会被合并为一个区间，
+//空区间会被忽略，这一过程称为区间集合的规范化。
+//与UnionOthers函数只能得到一个包络区间不同，RangeSet保留了区间之间的空隙，
+//因而可以进行完整的集合运算：并（Union）、交（Intersect）、差（Except）、对称差（SymmetricDifference）
+//以及在给定范围内求补（Complement）。
+//RangeSet是不可变的值类型，所有运算都返回新的集合，而不会修改原有的集合。
+//与ranges包中的其他函数一样，类型参数P是区间起点与终点的类型，R是实现了Range[P,R]接口的具体区间类型。
+type RangeSet[P any, R any] struct {
+	ranges []R //规范化之后的区间，有序、互不相交且互不相邻
+}
+
+//NewRangeSet函数用给定的一些区间（rs）创建一个规范化的区间集合。
+//给定的区间可以是无序的，也可以彼此相交或相邻。
+func NewRangeSet[P any, R any](rs ...R) RangeSet[P, R] {
+	return RangeSet[P, R]{ranges: normalize[P, R](rs)}
+}
+
+//Ranges方法返回集合中规范化之后的区间，返回的切片是一个副本，修改它不会影响集合本身。
+func (s RangeSet[P, R]) Ranges() []R {
+	result := make([]R, len(s.ranges))
+	copy(result, s.ranges)
+	return result
+}
+
+//Len方法返回集合中互不相交的区间的个数。
+func (s RangeSet[P, R]) Len() int {
+	return len(s.ranges)
+}
+
+//IsEmpty方法判断集合是否为空集，也就是不包含任何区间。
+func (s RangeSet[P, R]) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+//Add方法返回在集合中加入区间r之后的新集合。
+func (s RangeSet[P, R]) Add(r R) RangeSet[P, R] {
+	return s.Union(NewRangeSet[P, R](r))
+}
+
+//Remove方法返回在集合中去掉区间r之后的新集合。
+func (s RangeSet[P, R]) Remove(r R) RangeSet[P, R] {
+	return s.Except(NewRangeSet[P, R](r))
+}
+
+//Contains方法判断点p是否属于集合中的某个区间。
+func (s RangeSet[P, R]) Contains(p P) bool {
+	i := s.searchLower(endpoint[P]{p, Closed})
+	return i >= 0 && typeTo[R, Range[P, R]](s.ranges[i]).IsIncludedPoint(p)
+}
+
+//ContainsRange方法判断区间r是否完全被集合所覆盖，也就是r是否是集合中某个区间的子集。
+//由于集合中的区间互不相邻，所以r只能被集合中的一个区间所包含。空区间总是被覆盖的。
+func (s RangeSet[P, R]) ContainsRange(r R) bool {
+	rr := typeTo[R, Range[P, R]](r)
+	if IsEmpty(rr) {
+		return true
+	}
+	lower, _ := endpoints(rr)
+	i := s.searchLower(lower)
+	return i >= 0 && IsIncluded(typeTo[R, Range[P, R]](s.ranges[i]), rr)
+}
+
+//Span方法返回包含集合中所有区间的最小区间，如果集合为空，返回false。
+func (s RangeSet[P, R]) Span() (bool, R) {
+	var result R
+	if len(s.ranges) == 0 {
+		return false, result
+	}
+	first := typeTo[R, Range[P, R]](s.ranges[0])
+	_, result = Union(first, typeTo[R, Range[P, R]](s.ranges[len(s.ranges)-1]))
+	return true, result
+}
+
+//Union方法求集合与另一个集合（other）的并集。
+func (s RangeSet[P, R]) Union(other RangeSet[P, R]) RangeSet[P, R] {
+	all := make([]R, 0, len(s.ranges)+len(other.ranges))
+	all = append(all, s.ranges...)
+	all = append(all, other.ranges...)
+	return RangeSet[P, R]{ranges: normalize[P, R](all)}
+}
+
+//Intersect方法求集合与另一个集合（other）的交集。
+//由于两个集合中的区间都是有序的，所以只需要对两个集合同时进行一次顺序扫描。
+func (s RangeSet[P, R]) Intersect(other RangeSet[P, R]) RangeSet[P, R] {
+	var result []R
+	i, j := 0, 0
+	for i < len(s.ranges) && j < len(other.ranges) {
+		a := typeTo[R, Range[P, R]](s.ranges[i])
+		b := typeTo[R, Range[P, R]](other.ranges[j])
+		if ok, r := Intersect(a, b); ok {
+			result = append(result, r)
+		}
+		//终点在前的区间不会再与对方后续的区间相交
+		if endsNotAfter(a, b) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return RangeSet[P, R]{ranges: result}
+}
+
+//Except方法求集合与另一个集合（other）的差集，也就是集合去掉other所覆盖部分之后的剩余部分。
+func (s RangeSet[P, R]) Except(other RangeSet[P, R]) RangeSet[P, R] {
+	var result []R
+	j := 0
+	for _, r := range s.ranges {
+		rr := typeTo[R, Range[P, R]](r)
+		//跳过完全在r之前的区间，它们也一定在后续区间之前
+		for j < len(other.ranges) && IsBefore(typeTo[R, Range[P, R]](other.ranges[j]), rr) {
+			j++
+		}
+		result = append(result, exceptSorted(rr, other.ranges[j:])...)
+	}
+	return RangeSet[P, R]{ranges: result}
+}
+
+//SymmetricDifference方法求集合与另一个集合（other）的对称差，也就是只属于其中一个集合的部分。
+func (s RangeSet[P, R]) SymmetricDifference(other RangeSet[P, R]) RangeSet[P, R] {
+	return s.Except(other).Union(other.Except(s))
+}
+
+//Complement方法求集合在给定范围（bounds）之内的补集，也就是bounds中不被集合覆盖的部分。
+func (s RangeSet[P, R]) Complement(bounds R) RangeSet[P, R] {
+	b := typeTo[R, Range[P, R]](bounds)
+	if IsEmpty(b) {
+		return RangeSet[P, R]{}
+	}
+	return RangeSet[P, R]{ranges: exceptSorted(b, s.ranges)}
+}
+
+//Equal方法判断集合是否与另一个集合（other）相等，也就是二者规范化之后的区间完全相同。
+func (s RangeSet[P, R]) Equal(other RangeSet[P, R]) bool {
+	if len(s.ranges) != len(other.ranges) {
+		return false
+	}
+	for i := range s.ranges {
+		if !Equal(typeTo[R, Range[P, R]](s.ranges[i]), typeTo[R, Range[P, R]](other.ranges[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+//String方法将集合化为字符串，格式为{[start1,end1),[start2,end2)}。
+func (s RangeSet[P, R]) String() string {
+	strs := make([]string, 0, len(s.ranges))
+	for _, r := range s.ranges {
+		strs = append(strs, typeTo[R, Range[P, R]](r).String())
+	}
+	return "{" + strings.Join(strs, ",") + "}"
+}
+
+//searchLower方法使用二分查找，返回集合中起点不在下端点lower之后的最后一个区间的下标，
+//如果这样的区间不存在，返回-1。只有该区间才有可能包含以lower为起点的点或区间。
+func (s RangeSet[P, R]) searchLower(lower endpoint[P]) int {
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		r := typeTo[R, Range[P, R]](s.ranges[i])
+		rLower, _ := endpoints(r)
+		return compareLower(r, rLower, lower) > 0
+	})
+	return i - 1
+}
+
+/////////////////////////////下面是区间集合运算使用的内部函数////////////////////////////////
+
+//startsBefore函数判断区间a的起点是否在区间b的起点之前。
+func startsBefore[P any, R any](a, b Range[P, R]) bool {
+	aLower, _ := endpoints(a)
+	bLower, _ := endpoints(b)
+	return compareLower(a, aLower, bLower) < 0
+}
+
+//endsNotAfter函数判断区间a的终点是否不在区间b的终点之后。
+func endsNotAfter[P any, R any](a, b Range[P, R]) bool {
+	_, aUpper := endpoints(a)
+	_, bUpper := endpoints(b)
+	return compareUpper(a, aUpper, bUpper) <= 0
+}
+
+//normalize函数对一组区间进行规范化：去掉空区间，按起点排序，并合并相交或相邻的区间。
+func normalize[P any, R any](rs []R) []R {
+	sorted := make([]R, 0, len(rs))
+	for _, r := range rs {
+		if !IsEmpty(typeTo[R, Range[P, R]](r)) {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return startsBefore(typeTo[R, Range[P, R]](sorted[i]), typeTo[R, Range[P, R]](sorted[j]))
+	})
+	return MergeSorted[P, R](sorted)
+}
+
+//exceptSorted函数从区间r中依次去掉一组有序且互不相交的区间（others），返回按顺序排列的剩余区间。
+//others中第一个在r之后的区间之后的所有区间都不会被处理。
+func exceptSorted[P any, R any](r Range[P, R], others []R) []R {
+	var result []R
+	rest := r
+	for _, o := range others {
+		other := typeTo[R, Range[P, R]](o)
+		if !IsIntersected(rest, other) {
+			if IsBefore(other, rest) {
+				continue
+			}
+			break
+		}
+		r1, r2 := Except(rest, other)
+		rest = nil
+		for _, piece := range []R{r1, r2} {
+			p := typeTo[R, Range[P, R]](piece)
+			if IsEmpty(p) {
+				continue
+			}
+			//在other之前的部分不会再受后续区间的影响，在other之后的部分继续参与计算
+			if IsBefore(p, other) {
+				result = append(result, piece)
+			} else {
+				rest = p
+			}
+		}
+		if rest == nil {
+			return result
+		}
+	}
+	return append(result, typeTo[Range[P, R], R](rest))
+}
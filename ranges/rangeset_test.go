@@ -0,0 +1,47 @@
+package ranges
+
+import "testing"
+
+func TestRangeSet(t *testing.T) {
+	type set = RangeSet[int, NumberRange[int]]
+	nr := CreateNumberRange[int]
+	a := NewRangeSet[int, NumberRange[int]](nr(5, 7), nr(0, 2), nr(1, 3), nr(3, 4), nr(9, 9), nr(10, 12))
+	if got, want := a.String(), "{[0,4),[5,7),[10,12)}"; got != want {
+		t.Errorf("normalize: got %s, want %s", got, want)
+	}
+	b := NewRangeSet[int, NumberRange[int]](nr(2, 6), nr(11, 20))
+	cases := []struct {
+		name string
+		got  set
+		want string
+	}{
+		{"union", a.Union(b), "{[0,7),[10,20)}"},
+		{"intersect", a.Intersect(b), "{[2,4),[5,6),[11,12)}"},
+		{"except", a.Except(b), "{[0,2),[6,7),[10,11)}"},
+		{"except reversed", b.Except(a), "{[4,5),[12,20)}"},
+		{"symmetric difference", a.SymmetricDifference(b), "{[0,2),[4,5),[6,7),[10,11),[12,20)}"},
+		{"complement", a.Complement(nr(-1, 11)), "{[-1,0),[4,5),[7,10)}"},
+		{"complement inside", a.Complement(nr(1, 3)), "{}"},
+		{"add", a.Add(nr(4, 5)), "{[0,7),[10,12)}"},
+		{"remove", a.Remove(nr(1, 11)), "{[0,1),[11,12)}"},
+	}
+	for _, c := range cases {
+		if c.got.String() != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, c.got.String(), c.want)
+		}
+	}
+	for p, want := range map[int]bool{-1: false, 0: true, 3: true, 4: false, 6: true, 7: false, 11: true, 12: false} {
+		if a.Contains(p) != want {
+			t.Errorf("Contains(%d) = %v, want %v", p, !want, want)
+		}
+	}
+	if !a.ContainsRange(nr(5, 7)) || a.ContainsRange(nr(3, 6)) || a.ContainsRange(nr(-2, 1)) {
+		t.Errorf("ContainsRange gives wrong result")
+	}
+	if ok, span := a.Span(); !ok || span.String() != "[0,12)" {
+		t.Errorf("Span: got %s", span.String())
+	}
+	if !a.Union(b).Equal(b.Union(a)) || a.Equal(b) {
+		t.Errorf("Equal gives wrong result")
+	}
+}
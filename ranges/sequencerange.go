@@ -1,9 +1,8 @@
 package ranges
 
 //Sequencable定义了具有先后顺序的类型接口，是对可以作为SeqRange区间起点和终点的类型P的一种约束。
-//Range 接口要求起点和终点的类型参数P必须是comparable类型，所以Sequencable首先应该是
-//comparable,在此基础上增加三个方法要求，Equal、Before和After，这三个方法用来判断点与点
-//之间的先后关系。
+//Sequencable首先应该是comparable,在此基础上增加三个方法要求，Equal、Before和After，这三个方法用来判断点与点
+//之间的先后关系。Range接口本身并不要求P是comparable类型，对于不可比较的类型，可以使用CmpRange。
 //之所以设计Sequencable约束并要求该约束具有上述三个方法，主要考虑对time.Time类型作为Rang起点与终点
 //类型的支持。time.Time具有上述三个方法。所以，具体类型time.Time符合Sequencable[time.Time]约束(接口)要求。
 
@@ -26,7 +25,7 @@ type Sequencable[S any] interface {
 	After(ohter S) bool
 }
 
-//CreateSeqRange函数用于给定的两个点创建一个区间，
+//CreateSeqRange函数用于给定的两个点创建一个左闭右开区间，
 //无论两个点的先后顺序如何，创建出来的区间的起点都会在终点之前。
 func CreateSeqRange[P Sequencable[T], T any](p1, p2 P) SeqRange[P, T] {
 	t2 := typeTo[P, T](p2)
@@ -37,38 +36,87 @@ func CreateSeqRange[P Sequencable[T], T any](p1, p2 P) SeqRange[P, T] {
 	}
 }
 
+//CreateSeqRangeWithBounds函数用于给定的两个点及其边界类型创建一个区间，
+//left是p1的边界类型，right是p2的边界类型。如果p1在p2之后，两个点连同其边界类型一起交换，
+//因而，创建出来的区间的起点都会在终点之前。
+//如果有端点是无界的（Unbounded），则两个点不会交换，无界端点的值被置为零值。
+func CreateSeqRangeWithBounds[P Sequencable[T], T any](p1, p2 P, left, right BoundType) SeqRange[P, T] {
+	if left == Unbounded || right == Unbounded {
+		var zero P
+		if left == Unbounded {
+			p1 = zero
+		}
+		if right == Unbounded {
+			p2 = zero
+		}
+		return SeqRange[P, T]{start: p1, end: p2, bounds: makeBoundPair(left, right)}
+	}
+	if p1.After(typeTo[P, T](p2)) {
+		return SeqRange[P, T]{start: p2, end: p1, bounds: makeBoundPair(right, left)}
+	}
+	return SeqRange[P, T]{start: p1, end: p2, bounds: makeBoundPair(left, right)}
+}
+
+//CreateSeqRangeFrom函数创建一个以start为起点、终点无界的区间，比如，[start,+∞)，
+//left是起点的边界类型。
+func CreateSeqRangeFrom[P Sequencable[T], T any](start P, left BoundType) SeqRange[P, T] {
+	return CreateSeqRangeWithBounds[P, T](start, start, left, Unbounded)
+}
+
+//CreateSeqRangeTo函数创建一个起点无界、以end为终点的区间，比如，(-∞,end)，
+//right是终点的边界类型。
+func CreateSeqRangeTo[P Sequencable[T], T any](end P, right BoundType) SeqRange[P, T] {
+	return CreateSeqRangeWithBounds[P, T](end, end, Unbounded, right)
+}
+
 //SeqRange[P Sequencable[T], T any]定义了各类有顺序元素组成的区间类型，该类型的区间
-//满足Range[P,SeqRange[P,T]接口。
+//满足Range[P,SeqRange[P,T]接口。零值的SeqRange是左闭右开区间。
 type SeqRange[P Sequencable[T], T any] struct {
-	start P
-	end   P
+	start  P
+	end    P
+	bounds boundPair //起点与终点的边界类型
 }
 
 func (sr SeqRange[P, T]) Range(start, end P) SeqRange[P, T] {
 	return CreateSeqRange[P, T](start, end)
 }
 
+func (sr SeqRange[P, T]) RangeWithBounds(start, end P, left, right BoundType) SeqRange[P, T] {
+	return CreateSeqRangeWithBounds[P, T](start, end, left, right)
+}
+
 func (sr SeqRange[P, T]) DeRange() (start, end P) {
 	return sr.start, sr.end
 }
 
+func (sr SeqRange[P, T]) Bounds() (left, right BoundType) {
+	return sr.bounds.types()
+}
+
 func (sr SeqRange[P, T]) IsIncludedPoint(p P) bool {
 	var pt T = typeTo[P, T](p)
-	return (sr.start.Before(pt) || sr.start.Equal(pt)) && sr.end.After(pt)
+	left, right := sr.Bounds()
+	return (left == Unbounded || sr.start.Before(pt) || sr.start.Equal(pt) && left == Closed) &&
+		(right == Unbounded || sr.end.After(pt) || sr.end.Equal(pt) && right == Closed)
 }
 
 func (sr SeqRange[P, T]) IsBeforePoint(p P) bool {
 	var pt T = typeTo[P, T](p)
-	return sr.end.Equal(pt) || sr.end.Before(pt)
+	_, right := sr.Bounds()
+	return right != Unbounded && (sr.end.Before(pt) || sr.end.Equal(pt) && right == Open)
 }
 
 func (sr SeqRange[P, T]) IsAfterPoint(p P) bool {
 	var pt T = typeTo[P, T](p)
-	return sr.start.After(pt)
+	left, _ := sr.Bounds()
+	return left != Unbounded && (sr.start.After(pt) || sr.start.Equal(pt) && left == Open)
 }
 
 /////////////////////////////////////////////////////////////////////////////
 ///////////////以下方法使用ranges包的函数完成，如果去除这些方法，则具体与抽象可以完全分离///////////////
+func (sr SeqRange[P, T]) IsEmpty() bool {
+	return IsEmpty[P, SeqRange[P, T]](sr)
+}
 func (sr SeqRange[P, T]) IsPoint() bool {
 	return IsPoint[P, SeqRange[P, T]](sr)
 }
@@ -102,9 +150,32 @@ func (sr SeqRange[P, T]) UnionOthers(others []SeqRange[P, T]) (bool, SeqRange[P,
 func (sr SeqRange[P, T]) Except(other SeqRange[P, T]) (r1, r2 SeqRange[P, T]) {
 	return Except[P, SeqRange[P, T]](sr, other)
 }
+func (sr SeqRange[P, T]) ExceptOthers(others []SeqRange[P, T]) []SeqRange[P, T] {
+	return ExceptOthers[P, SeqRange[P, T]](sr, others)
+}
 func (sr SeqRange[P, T]) IsBefore(other SeqRange[P, T]) bool {
 	return IsBefore[P, SeqRange[P, T]](sr, other)
 }
 func (sr SeqRange[P, T]) IsAfter(other SeqRange[P, T]) bool {
 	return IsAfter[P, SeqRange[P, T]](sr, other)
 }
+
+//MarshalJSON方法将区间编码为JSON对象，比如，{"start":1,"end":5}，
+//非缺省的边界类型编码为bounds字段，比如，{"start":1,"end":5,"bounds":"[]"}，无界的端点不被编码。
+func (sr SeqRange[P, T]) MarshalJSON() ([]byte, error) {
+	return marshalRangeJSON[P, SeqRange[P, T]](sr)
+}
+
+//UnmarshalJSON方法将JSON数据解码为区间，JSON数据既可以是对象形式，比如，{"start":1,"end":5}，
+//也可以是字符串形式，比如，"[1,5)"。JSON数据为null时，区间保持不变。
+func (sr *SeqRange[P, T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	result, err := unmarshalRangeJSON[P, SeqRange[P, T]](*sr, data)
+	if err != nil {
+		return err
+	}
+	*sr = result
+	return nil
+}
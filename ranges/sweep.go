@@ -0,0 +1,99 @@
+package ranges
+
+import "sort"
+
+//DepthSegment是区间深度剖面中的一段，Depth是该段中每一个点被给定区间覆盖的次数，也就是重叠深度。
+//比如，预订数据中，重叠深度就是同一时刻的并发会话数。
+type DepthSegment[R any] struct {
+	Range R
+	Depth int
+}
+
+//DepthProfile函数使用扫描线算法计算一组区间（rs）的重叠深度剖面，返回按照先后顺序排列、互不相交的深度段。
+//只有深度大于0的部分出现在结果中，相邻的两段深度一定不同，比如，[1,4)、[2,6)、[5,7)的深度剖面是
+//[1,2)×1、[2,4)×2、[4,5)×1、[5,6)×2、[6,7)×1。计算依据区间的边界类型，比如，[1,2]与[2,3)的深度剖面是
+//[1,2)×1、[2,2]×2、(2,3)×1，空区间会被忽略。时间复杂度是O(n log n)。
+func DepthProfile[P any, R any](rs []R) []DepthSegment[R] {
+	var proto Range[P, R]
+	events := make([]sweepEvent[P], 0, 2*len(rs))
+	for _, r := range rs {
+		rr := typeTo[R, Range[P, R]](r)
+		if IsEmpty(rr) {
+			continue
+		}
+		proto = rr
+		lower, upper := endpoints(rr)
+		events = append(events, sweepEvent[P]{lower, 1})
+		if upper.bound != Unbounded {
+			events = append(events, sweepEvent[P]{endpoint[P]{upper.value, upper.bound.flip()}, -1})
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareLower(proto, events[i].at, events[j].at) < 0
+	})
+	var result []DepthSegment[R]
+	var segmentLower endpoint[P]
+	depth := 0
+	for i := 0; i < len(events); {
+		at, delta := events[i].at, 0
+		for ; i < len(events) && compareLower(proto, events[i].at, at) == 0; i++ {
+			delta += events[i].delta
+		}
+		if delta == 0 {
+			continue
+		}
+		if depth > 0 {
+			segmentUpper := endpoint[P]{at.value, at.bound.flip()}
+			result = append(result, DepthSegment[R]{withEndpoints(proto, segmentLower, segmentUpper), depth})
+		}
+		depth += delta
+		segmentLower = at
+	}
+	if depth > 0 {
+		result = append(result, DepthSegment[R]{withEndpoints(proto, segmentLower, endpoint[P]{bound: Unbounded}), depth})
+	}
+	return result
+}
+
+//MaxDepth函数计算一组区间（rs）的最大重叠深度，也就是峰值并发数，并返回达到最大深度的各个区间，按照先后顺序排列。
+//rs中没有非空区间时，返回0与nil。
+func MaxDepth[P any, R any](rs []R) (int, []R) {
+	peak := 0
+	var where []R
+	for _, segment := range DepthProfile[P, R](rs) {
+		switch {
+		case segment.Depth > peak:
+			peak, where = segment.Depth, []R{segment.Range}
+		case segment.Depth == peak:
+			where = append(where, segment.Range)
+		}
+	}
+	return peak, where
+}
+
+//DepthAtLeast函数返回一组区间（rs）中重叠深度不小于k的部分，结果按照先后顺序排列，互不相交且互不相邻。
+//k不大于1时，结果就是rs合并之后的区间，与Coalesce函数相同。
+func DepthAtLeast[P any, R any](rs []R, k int) []R {
+	var result []R
+	c := NewCoalescer[P, R](func(r R) {
+		result = append(result, r)
+	})
+	for _, segment := range DepthProfile[P, R](rs) {
+		if segment.Depth >= k {
+			c.merge(typeTo[R, Range[P, R]](segment.Range))
+		}
+	}
+	c.Flush()
+	return result
+}
+
+//sweepEvent是扫描线算法中的事件，at是事件发生的位置，delta是深度的变化。
+//at以下端点的形式表示：区间的起点就是其下端点，区间的终点则转换为紧随其后的那一段的下端点，
+//比如，终点是开边界的3，紧随其后的那一段从闭边界的3开始，因而，所有的事件都可以按照下端点的先后顺序排序。
+type sweepEvent[P any] struct {
+	at    endpoint[P]
+	delta int
+}
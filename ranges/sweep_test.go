@@ -0,0 +1,66 @@
+package ranges
+
+import (
+	"testing"
+	"time"
+)
+
+func profileString(profile []DepthSegment[NumberRange[int]]) string {
+	s := ""
+	for _, segment := range profile {
+		s += segment.Range.String() + "×" + v2s(segment.Depth) + " "
+	}
+	return s
+}
+
+func TestDepthProfile(t *testing.T) {
+	nr := CreateNumberRangeWithBounds[int]
+	cases := []struct {
+		rs   []NumberRange[int]
+		want string
+	}{
+		{[]NumberRange[int]{CreateNumberRange(5, 7), CreateNumberRange(1, 4), CreateNumberRange(2, 6)}, "[1,2)×1 [2,4)×2 [4,5)×1 [5,6)×2 [6,7)×1 "},
+		{[]NumberRange[int]{nr(1, 2, Closed, Closed), CreateNumberRange(2, 3)}, "[1,2)×1 [2,3)×2 "},
+		{[]NumberRange[int]{CreateNumberRange(1, 2), CreateNumberRange(2, 3)}, "[1,3)×1 "},
+		{[]NumberRange[int]{CreateNumberRange(1, 2), CreateNumberRange(3, 4), CreateNumberRange(5, 5)}, "[1,2)×1 [3,4)×1 "},
+		{[]NumberRange[int]{CreateNumberRangeFrom(3, Open), CreateNumberRangeTo(5, Closed), CreateNumberRange(1, 2)}, "(-∞,1)×1 [1,2)×2 [2,4)×1 [4,6)×2 [6,+∞)×1 "},
+		{[]NumberRange[int]{CreateNumberRange(1, 3), CreateNumberRange(1, 3)}, "[1,3)×2 "},
+		{nil, ""},
+	}
+	for _, c := range cases {
+		if got := profileString(DepthProfile[int, NumberRange[int]](c.rs)); got != c.want {
+			t.Errorf("DepthProfile(%v): got %s, want %s", c.rs, got, c.want)
+		}
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	rs := []NumberRange[int]{CreateNumberRange(5, 7), CreateNumberRange(1, 4), CreateNumberRange(2, 6), CreateNumberRange(9, 10)}
+	max, where := MaxDepth[int, NumberRange[int]](rs)
+	if max != 2 || len(where) != 2 || where[0].String() != "[2,4)" || where[1].String() != "[5,6)" {
+		t.Errorf("MaxDepth: got %d at %v", max, where)
+	}
+	if max, where := MaxDepth[int, NumberRange[int]](nil); max != 0 || where != nil {
+		t.Errorf("MaxDepth(nil): got %d at %v", max, where)
+	}
+	if got := DepthAtLeast[int, NumberRange[int]](rs, 2); len(got) != 2 || got[0].String() != "[2,4)" || got[1].String() != "[5,6)" {
+		t.Errorf("DepthAtLeast(2): got %v", got)
+	}
+	if got := DepthAtLeast[int, NumberRange[int]](rs, 1); len(got) != 2 || got[0].String() != "[1,7)" {
+		t.Errorf("DepthAtLeast(1): got %v", got)
+	}
+	if got := DepthAtLeast[int, NumberRange[int]](rs, 3); len(got) != 0 {
+		t.Errorf("DepthAtLeast(3): got %v", got)
+	}
+
+	//同一时刻最多有3个会话
+	start := time.Date(2022, 1, 1, 9, 0, 0, 0, time.UTC)
+	session := func(from, to int) TimeInterval {
+		return CreateTimeInterval(start.Add(time.Duration(from)*time.Minute), start.Add(time.Duration(to)*time.Minute))
+	}
+	sessions := []TimeInterval{session(0, 30), session(10, 40), session(20, 25), session(25, 50), session(45, 60)}
+	max, peaks := MaxDepth[time.Time, TimeInterval](sessions)
+	if max != 3 || len(peaks) != 1 || Tintvl2Str(peaks[0]) != "[2022-01-01 09:20:00,2022-01-01 09:30:00)" {
+		t.Errorf("MaxDepth: got %d at %v", max, peaks)
+	}
+}
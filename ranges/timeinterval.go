@@ -2,12 +2,29 @@ package ranges
 
 import "time"
 
+//TimeInterval是由time.Time类型的起止时间构成的时间段。
+//TimeInterval编码为JSON时，起止时间使用RFC 3339格式，比如，{"start":"2022-01-01T00:00:00Z","end":"2022-01-02T00:00:00Z"}。
 type TimeInterval = SeqRange[time.Time, time.Time]
 
 func CreateTimeInterval(t1, t2 time.Time) TimeInterval {
 	return CreateSeqRange[time.Time, time.Time](t1, t2)
 }
 
+//CreateTimeIntervalWithBounds函数用给定的两个时间点及其边界类型创建一个时间段。
+func CreateTimeIntervalWithBounds(t1, t2 time.Time, left, right BoundType) TimeInterval {
+	return CreateSeqRangeWithBounds[time.Time, time.Time](t1, t2, left, right)
+}
+
+//CreateTimeIntervalFrom函数创建一个从start开始、没有结束时间的时间段，比如，[2022-01-01,+∞)。
+func CreateTimeIntervalFrom(start time.Time, left BoundType) TimeInterval {
+	return CreateSeqRangeFrom[time.Time, time.Time](start, left)
+}
+
+//CreateTimeIntervalTo函数创建一个没有开始时间、到end结束的时间段，比如，(-∞,2022-01-01)。
+func CreateTimeIntervalTo(end time.Time, right BoundType) TimeInterval {
+	return CreateSeqRangeTo[time.Time, time.Time](end, right)
+}
+
 /**
 由于TimeInterval是由泛型类型SeqRange实例化产生的类型，
 go.18版本不允许有分型类型实例化所产生的类型定义自己的方法，比如：
@@ -53,7 +70,8 @@ func (nc *TICycleFunc) OfCycles(t TimeInterval, n int, c TimeCycle) TimeInterval
 	var duration = time.Duration(n * c.GetCount() * int(c.GetUnit()))
 	start := rStart.Add(duration)
 	end := rEnd.Add(duration)
-	return t.Range(start, end)
+	left, right := t.Bounds()
+	return t.RangeWithBounds(start, end, left, right)
 }
 
 //TPCycleFunc是时间点（Time Point）的周期计算函数
@@ -0,0 +1,235 @@
+package ranges
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//oneDay是一天的时长，也就是一天中时刻的取值范围[00:00,24:00)的长度。
+const oneDay = 24 * time.Hour
+
+//TimeOfDay定义了一天中的时刻，也就是当地时间从00:00开始经过的时长，取值范围是[00:00,24:00]，
+//其中，24:00只用作时段的终点，表示一天的结束。
+type TimeOfDay time.Duration
+
+//CreateTimeOfDay函数用时、分、秒创建一天中的时刻，比如，CreateTimeOfDay(22, 30, 0)表示22:30。
+func CreateTimeOfDay(hour, min, sec int) TimeOfDay {
+	return TimeOfDay(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second)
+}
+
+//TimeOfDayOf函数返回时间t在其自身时区中的时刻，也就是t的当地时间从当天00:00开始经过的时长，不受夏令时的影响，
+//比如，夏令时开始的当天，当地时间03:00的时刻仍然是03:00。
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, min, sec := t.Clock()
+	return CreateTimeOfDay(hour, min, sec) + TimeOfDay(t.Nanosecond())
+}
+
+//ParseTimeOfDay函数解析“15:04”或者“15:04:05”格式的时刻，秒可以带有小数，比如，“15:04:05.5”，
+//“24:00”表示一天的结束。
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) < 2 || len(fields) > 3 || len(fields[0]) != 2 || len(fields[1]) != 2 {
+		return 0, &ParseError{Text: s, Msg: "time of day must be hh:mm or hh:mm:ss"}
+	}
+	hour, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, &ParseError{Text: s, Msg: "invalid hour", Err: err}
+	}
+	min, err := strconv.Atoi(fields[1])
+	if err != nil || min < 0 || min > 59 {
+		return 0, &ParseError{Text: s, Msg: "invalid minute", Err: err}
+	}
+	var sec float64
+	if len(fields) == 3 {
+		if len(fields[2]) < 2 || fields[2][0] < '0' || fields[2][0] > '9' {
+			return 0, &ParseError{Text: s, Msg: "invalid second"}
+		}
+		sec, err = strconv.ParseFloat(fields[2], 64)
+		if err != nil || sec >= 60 {
+			return 0, &ParseError{Text: s, Msg: "invalid second", Err: err}
+		}
+	}
+	tod := CreateTimeOfDay(hour, min, 0) + TimeOfDay(sec*float64(time.Second)+0.5)
+	if hour < 0 || tod > TimeOfDay(oneDay) {
+		return 0, &ParseError{Text: s, Msg: "time of day is out of range"}
+	}
+	return tod, nil
+}
+
+//On方法返回日期d在时区loc中当地时间为tod的时刻，24:00是第二天的00:00。
+//如果当地时间因夏令时而不存在或者出现两次，结果按照time.Date的规则确定。
+func (tod TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	hour := time.Duration(tod) / time.Hour
+	min := time.Duration(tod) % time.Hour / time.Minute
+	nsec := time.Duration(tod) % time.Minute
+	return time.Date(d.Year, d.Month, d.Day, int(hour), int(min), 0, int(nsec), loc)
+}
+
+//String方法使用“15:04”格式输出时刻，有秒时使用“15:04:05”格式，秒的小数部分去掉末尾的0，比如，“15:04:05.5”。
+func (tod TimeOfDay) String() string {
+	d := time.Duration(tod)
+	s := fmt.Sprintf("%02d:%02d", d/time.Hour, d%time.Hour/time.Minute)
+	if sec := d % time.Minute; sec != 0 {
+		s += fmt.Sprintf(":%02d", sec/time.Second)
+		if nsec := sec % time.Second; nsec != 0 {
+			s += strings.TrimRight(fmt.Sprintf(".%09d", nsec), "0")
+		}
+	}
+	return s
+}
+
+//TimeOfDayRange定义了一天中的时段，比如，白班[08:00,16:00)、夜班[22:00,06:00)或者谷电时段[23:00,07:00)。
+//时段总是左闭右开的，起点在终点之后的时段跨越午夜，也就是从起点开始，经过24:00，到第二天的终点结束。
+//因此，与CreateSeqRange不同，CreateTimeOfDayRange不会交换起点与终点。
+//TimeOfDayRange的零值是空时段，[00:00,24:00)是全天。
+type TimeOfDayRange struct {
+	start  TimeOfDay     //起点，取值范围是[00:00,24:00)
+	length time.Duration //时长，取值范围是[0,24h]
+}
+
+//CreateTimeOfDayRange函数用起点start与终点end创建一个左闭右开的时段，起点在终点之后时，时段跨越午夜，
+//起点等于终点时，时段是空的，比如，[24:00,24:00)，只有[00:00,24:00)是全天。超出[00:00,24:00]的时刻按24小时取模。
+func CreateTimeOfDayRange(start, end TimeOfDay) TimeOfDayRange {
+	return timeOfDayRangeOf(CreateCircularRange(TimeOfDay(oneDay), start, end))
+}
+
+//CreateFullDayRange函数创建全天的时段[00:00,24:00)。
+func CreateFullDayRange() TimeOfDayRange {
+	return TimeOfDayRange{length: oneDay}
+}
+
+//Start方法返回时段的起点。
+func (tr TimeOfDayRange) Start() TimeOfDay {
+	return tr.start
+}
+
+//End方法返回时段的终点，跨越午夜的时段，终点在起点之前。终点是午夜时返回24:00，而不是00:00。
+func (tr TimeOfDayRange) End() TimeOfDay {
+	end := tr.start + TimeOfDay(tr.length)
+	if end > TimeOfDay(oneDay) {
+		end -= TimeOfDay(oneDay)
+	}
+	return end
+}
+
+//Duration方法返回时段的时长，比如，[22:00,06:00)的时长是8小时。
+func (tr TimeOfDayRange) Duration() time.Duration {
+	return tr.length
+}
+
+//IsEmpty方法判断时段是否是空的。
+func (tr TimeOfDayRange) IsEmpty() bool {
+	return tr.length == 0
+}
+
+//IsFullDay方法判断时段是否是全天。
+func (tr TimeOfDayRange) IsFullDay() bool {
+	return tr.length == oneDay
+}
+
+//IsWrapped方法判断时段是否跨越午夜，也就是起点在终点之后，比如，[22:00,06:00)。
+func (tr TimeOfDayRange) IsWrapped() bool {
+	return time.Duration(tr.start)+tr.length > oneDay
+}
+
+//IncludesTimeOfDay方法判断时刻tod是否在时段之中。
+func (tr TimeOfDayRange) IncludesTimeOfDay(tod TimeOfDay) bool {
+	return tr.circular().IsIncludedPoint(tod)
+}
+
+//IncludesTime方法判断时间t在时区loc中的当地时刻是否在时段之中，比如，对于夜班[22:00,06:00)，
+//北京时间2022-01-01T23:00与2022-01-02T05:00都在时段之中。loc为nil时，使用t自身的时区。
+func (tr TimeOfDayRange) IncludesTime(t time.Time, loc *time.Location) bool {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return tr.IncludesTimeOfDay(TimeOfDayOf(t))
+}
+
+//Equal方法判断两个时段是否相同，所有的空时段都是相同的。
+func (tr TimeOfDayRange) Equal(other TimeOfDayRange) bool {
+	return tr == other
+}
+
+//Intersect方法求两个时段的交集。两个时段可能在两处相交，比如，[22:00,06:00)与[04:00,23:00)的交集是
+//[04:00,06:00)与[22:00,23:00)，所以，交集是按起点排序的零个、一个或者两个时段。
+func (tr TimeOfDayRange) Intersect(other TimeOfDayRange) []TimeOfDayRange {
+	return timeOfDayRangesOf(tr.circular().Intersect(other.circular()))
+}
+
+//Except方法求时段tr减去other的差集，比如，[22:00,06:00)减去[00:00,01:00)的结果是[01:00,06:00)与[22:00,24:00)，
+//所以，差集是按起点排序的零个、一个或者两个时段。
+func (tr TimeOfDayRange) Except(other TimeOfDayRange) []TimeOfDayRange {
+	return timeOfDayRangesOf(tr.circular().Except(other.circular()))
+}
+
+//Union方法求两个时段的并集，返回的布尔值表示两个时段是否相交或者相邻，只有这时并集才是一个时段。
+//比如，[22:00,02:00)与[01:00,06:00)的并集是[22:00,06:00)，[08:00,20:00)与[18:00,10:00)的并集是全天。
+//两个时段不相连时，返回false，以及从tr的起点开始、顺时针到other的终点为止的时段。空时段与任何时段的并集都是该时段本身。
+func (tr TimeOfDayRange) Union(other TimeOfDayRange) (bool, TimeOfDayRange) {
+	ok, union := tr.circular().Union(other.circular())
+	return ok, timeOfDayRangeOf(union)
+}
+
+//OnDate方法将时段投影到时区loc中的日期d上，返回当天落在时段之中的时间段，按时间先后排序。
+//不跨越午夜的时段投影为一个时间段；跨越午夜的时段投影为当天开始的部分与当天结束的部分两个时间段，
+//比如，夜班[22:00,06:00)投影到2022-01-01上，是[2022-01-01 00:00,2022-01-01 06:00)与[2022-01-01 22:00,2022-01-02 00:00)。
+//需要从d开始的一整个时段时，使用StartingOn方法。
+func (tr TimeOfDayRange) OnDate(d Date, loc *time.Location) []TimeInterval {
+	if tr.IsEmpty() {
+		return nil
+	}
+	if !tr.IsWrapped() {
+		return []TimeInterval{CreateTimeInterval(tr.start.On(d, loc), tr.End().On(d, loc))}
+	}
+	midnight := d.In(loc)
+	return []TimeInterval{
+		CreateTimeInterval(midnight, tr.End().On(d, loc)),
+		CreateTimeInterval(tr.start.On(d, loc), d.AddDays(1).In(loc)),
+	}
+}
+
+//StartingOn方法返回时区loc中从日期d开始的时段，跨越午夜的时段在第二天结束，
+//比如，夜班[22:00,06:00)从2022-01-01开始的时段是[2022-01-01 22:00,2022-01-02 06:00)。空时段返回空的时间段。
+func (tr TimeOfDayRange) StartingOn(d Date, loc *time.Location) TimeInterval {
+	if tr.IsEmpty() {
+		return TimeInterval{}
+	}
+	end := tr.End().On(d, loc)
+	if tr.IsWrapped() {
+		end = tr.End().On(d.AddDays(1), loc)
+	}
+	return CreateTimeInterval(tr.start.On(d, loc), end)
+}
+
+//String方法输出时段的文本形式，比如，[22:00,06:00)，空时段输出“empty”。
+func (tr TimeOfDayRange) String() string {
+	if tr.IsEmpty() {
+		return "empty"
+	}
+	return "[" + tr.start.String() + "," + tr.End().String() + ")"
+}
+
+//circular方法将时段转换为模为24小时的环上的区间。
+func (tr TimeOfDayRange) circular() CircularRange[TimeOfDay] {
+	return circularOfLength(TimeOfDay(oneDay), tr.start, TimeOfDay(tr.length))
+}
+
+//timeOfDayRangeOf函数将模为24小时的环上的区间转换为时段。
+func timeOfDayRangeOf(cr CircularRange[TimeOfDay]) TimeOfDayRange {
+	return TimeOfDayRange{start: cr.Start(), length: time.Duration(cr.Length())}
+}
+
+//timeOfDayRangesOf函数将模为24小时的环上的一组区间转换为时段。
+func timeOfDayRangesOf(crs []CircularRange[TimeOfDay]) []TimeOfDayRange {
+	if len(crs) == 0 {
+		return nil
+	}
+	result := make([]TimeOfDayRange, len(crs))
+	for i, cr := range crs {
+		result[i] = timeOfDayRangeOf(cr)
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package ranges
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	cases := []struct {
+		text string
+		want TimeOfDay
+	}{
+		{"22:00", CreateTimeOfDay(22, 0, 0)},
+		{"06:30:15", CreateTimeOfDay(6, 30, 15)},
+		{"00:00:00.5", TimeOfDay(500 * time.Millisecond)},
+		{"24:00", TimeOfDay(24 * time.Hour)},
+	}
+	for _, c := range cases {
+		got, err := ParseTimeOfDay(c.text)
+		if err != nil || got != c.want {
+			t.Errorf("ParseTimeOfDay(%q) = %s, %v", c.text, got, err)
+		}
+		if got.String() != c.text {
+			t.Errorf("String: got %s, want %s", got, c.text)
+		}
+	}
+	for _, s := range []string{"24:01", "7:00", "12:60", "12:00:-1", "noon"} {
+		if _, err := ParseTimeOfDay(s); err == nil {
+			t.Errorf("ParseTimeOfDay(%q) should fail", s)
+		}
+	}
+	shanghai := time.FixedZone("CST", 8*3600)
+	if tod := TimeOfDayOf(time.Date(2022, 1, 1, 14, 30, 0, 0, time.UTC).In(shanghai)); tod != CreateTimeOfDay(22, 30, 0) {
+		t.Errorf("TimeOfDayOf: got %s", tod)
+	}
+	if got := TimeOfDay(24 * time.Hour).On(CreateDate(2022, 1, 31), time.UTC); !got.Equal(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("On: got %s", got)
+	}
+}
+
+func TestTimeOfDayRange(t *testing.T) {
+	hm := func(h int) TimeOfDay { return CreateTimeOfDay(h, 0, 0) }
+	night := CreateTimeOfDayRange(hm(22), hm(6))
+	if night.String() != "[22:00,06:00)" || !night.IsWrapped() || night.Duration() != 8*time.Hour {
+		t.Errorf("night shift: got %s", night)
+	}
+	if !CreateTimeOfDayRange(hm(5), hm(5)).IsEmpty() || !CreateTimeOfDayRange(hm(24), hm(24)).IsEmpty() || !CreateTimeOfDayRange(0, hm(24)).IsFullDay() || CreateTimeOfDayRange(hm(20), hm(24)).IsWrapped() {
+		t.Errorf("empty, full-day and midnight-ending ranges: wrong result")
+	}
+	for h, want := range map[int]bool{21: false, 22: true, 23: true, 0: true, 5: true, 6: false, 12: false} {
+		if got := night.IncludesTimeOfDay(hm(h)); got != want {
+			t.Errorf("IncludesTimeOfDay(%d:00) = %v", h, got)
+		}
+	}
+	shanghai := time.FixedZone("CST", 8*3600)
+	instant := time.Date(2022, 1, 1, 15, 0, 0, 0, time.UTC) //北京时间23:00
+	if !night.IncludesTime(instant, shanghai) || night.IncludesTime(instant, nil) {
+		t.Errorf("IncludesTime: wrong result")
+	}
+
+	intersectCases := []struct {
+		a, b TimeOfDayRange
+		want string
+	}{
+		{night, CreateTimeOfDayRange(hm(4), hm(23)), "[04:00,06:00) [22:00,23:00)"},
+		{night, CreateTimeOfDayRange(hm(23), hm(2)), "[23:00,02:00)"},
+		{night, CreateTimeOfDayRange(hm(8), hm(20)), ""},
+		{night, CreateFullDayRange(), "[22:00,06:00)"},
+		{CreateTimeOfDayRange(hm(1), hm(3)), night, "[01:00,03:00)"},
+	}
+	for _, c := range intersectCases {
+		if got := joinTimeOfDayRanges(c.a.Intersect(c.b)); got != c.want {
+			t.Errorf("%s ∩ %s = %q, want %q", c.a, c.b, got, c.want)
+		}
+	}
+
+	if got := joinTimeOfDayRanges(night.Except(CreateTimeOfDayRange(0, hm(1)))); got != "[01:00,06:00) [22:00,24:00)" {
+		t.Errorf("Except: got %q", got)
+	}
+
+	unionCases := []struct {
+		a, b      TimeOfDayRange
+		connected bool
+		want      string
+	}{
+		{CreateTimeOfDayRange(hm(22), hm(2)), CreateTimeOfDayRange(hm(1), hm(6)), true, "[22:00,06:00)"},
+		{CreateTimeOfDayRange(hm(1), hm(6)), CreateTimeOfDayRange(hm(22), hm(1)), true, "[22:00,06:00)"},
+		{CreateTimeOfDayRange(hm(8), hm(20)), CreateTimeOfDayRange(hm(18), hm(10)), true, "[00:00,24:00)"},
+		{CreateTimeOfDayRange(hm(6), hm(22)), night, true, "[00:00,24:00)"},
+		{night, CreateTimeOfDayRange(hm(8), hm(12)), false, "[22:00,12:00)"},
+		{TimeOfDayRange{}, night, true, "[22:00,06:00)"},
+	}
+	for _, c := range unionCases {
+		if ok, got := c.a.Union(c.b); ok != c.connected || got.String() != c.want {
+			t.Errorf("%s ∪ %s = %s, %v", c.a, c.b, got, ok)
+		}
+	}
+}
+
+func TestTimeOfDayRangeOnDate(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	day := CreateDate(2022, 1, 1)
+	at := func(d, h int) time.Time { return time.Date(2022, 1, d, h, 0, 0, 0, shanghai) }
+	night := CreateTimeOfDayRange(CreateTimeOfDay(22, 0, 0), CreateTimeOfDay(6, 0, 0))
+	got := night.OnDate(day, shanghai)
+	if len(got) != 2 || !got[0].Equal(CreateTimeInterval(at(1, 0), at(1, 6))) || !got[1].Equal(CreateTimeInterval(at(1, 22), at(2, 0))) {
+		t.Errorf("OnDate: got %v", got)
+	}
+	shift := CreateTimeOfDayRange(CreateTimeOfDay(8, 0, 0), CreateTimeOfDay(16, 0, 0))
+	if got := shift.OnDate(day, shanghai); len(got) != 1 || !got[0].Equal(CreateTimeInterval(at(1, 8), at(1, 16))) {
+		t.Errorf("OnDate: got %v", got)
+	}
+	if got := night.StartingOn(day, shanghai); !got.Equal(CreateTimeInterval(at(1, 22), at(2, 6))) {
+		t.Errorf("StartingOn: got %s", Tintvl2Str(got))
+	}
+	if got := (TimeOfDayRange{}).OnDate(day, shanghai); got != nil {
+		t.Errorf("OnDate of an empty range: got %v", got)
+	}
+}
+
+func joinTimeOfDayRanges(rs []TimeOfDayRange) string {
+	s := ""
+	for i, r := range rs {
+		if i > 0 {
+			s += " "
+		}
+		s += r.String()
+	}
+	return s
+}
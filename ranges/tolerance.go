@@ -0,0 +1,90 @@
+package ranges
+
+import "math"
+
+//float约束了浮点数类型。
+type float interface {
+	~float32 | ~float64
+}
+
+//Tolerance[P float]定义了浮点数区间的容差比较模式，Epsilon是绝对容差，相差不超过Epsilon的两个端点被视为相同。
+//浮点数运算的舍入误差会使本应相邻的区间之间出现极小的空隙，比如，NRCycleFunc按周期0.1平移[0,0.1)时，
+//第6个周期的终点0.6与第7个周期的起点0.6000000000000001之间有空隙。NumberRange自身的Equal、Union等方法严格地比较端点，
+//而Tolerance的方法则忽略不超过Epsilon的差异，从而可以确定地合并这样的区间。
+//-Inf与+Inf被视为无界的端点（参见CreateNumberRangeWithBounds），无界的端点只与无界的端点相同。
+//零值的Tolerance与严格比较相同。
+type Tolerance[P float] struct {
+	Epsilon P
+}
+
+//Equal方法判断两个区间在容差范围内是否相等，也就是起点与终点分别相差不超过Epsilon，并且边界类型相同。
+//所有的空区间都是相等的。
+func (t Tolerance[P]) Equal(a, b NumberRange[P]) bool {
+	if aEmpty, bEmpty := a.IsEmpty(), b.IsEmpty(); aEmpty || bEmpty {
+		return aEmpty && bEmpty
+	}
+	aLeft, aRight := a.Bounds()
+	bLeft, bRight := b.Bounds()
+	if aLeft != bLeft || aRight != bRight {
+		return false
+	}
+	aStart, aEnd := a.DeRange()
+	bStart, bEnd := b.DeRange()
+	return (aLeft == Unbounded || t.isClose(aStart, bStart)) && (aRight == Unbounded || t.isClose(aEnd, bEnd))
+}
+
+//IsConnected方法判断两个区间在容差范围内是否相交或者相邻，也就是二者之间没有空隙，或者空隙的长度不超过Epsilon。
+//空区间与任何区间都是相连的。
+func (t Tolerance[P]) IsConnected(a, b NumberRange[P]) bool {
+	if a.IsEmpty() || b.IsEmpty() || isConnected[P, NumberRange[P]](a, b) {
+		return true
+	}
+	//不相连的两个区间，先结束区间的终点与后开始区间的起点一定都是有界的
+	if b.IsBefore(a) {
+		a, b = b, a
+	}
+	_, aEnd := a.DeRange()
+	bStart, _ := b.DeRange()
+	return t.isClose(aEnd, bStart)
+}
+
+//Union方法求两个区间的并集，也就是最小的起点与最大的终点所构成的区间，
+//返回的布尔值表示二者在容差范围内是否相连（参见IsConnected方法）。空区间与任何区间的并集都是该区间本身。
+func (t Tolerance[P]) Union(a, b NumberRange[P]) (bool, NumberRange[P]) {
+	_, span := a.Union(b)
+	return t.IsConnected(a, b), span
+}
+
+//Coalesce方法将一组区间按照起点排序，并合并在容差范围内相连的区间，返回有序的区间，
+//结果中相邻的两个区间之间的空隙都大于Epsilon。空区间会被忽略，给定的区间切片不会被修改。
+func (t Tolerance[P]) Coalesce(rs []NumberRange[P]) []NumberRange[P] {
+	sorted := Coalesce[P, NumberRange[P]](rs)
+	if len(sorted) == 0 {
+		return sorted
+	}
+	result := sorted[:1]
+	for _, r := range sorted[1:] {
+		last := &result[len(result)-1]
+		if isConnected, union := t.Union(*last, r); isConnected {
+			*last = union
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+//isClose函数判断两个有界的端点是否相差不超过Epsilon。
+func (t Tolerance[P]) isClose(a, b P) bool {
+	return math.Abs(float64(a)-float64(b)) <= float64(t.Epsilon)
+}
+
+//isNaN函数判断数字p是否是NaN，只有浮点数才可能是NaN。
+func isNaN[P number](p P) bool {
+	return p != p
+}
+
+//isInf函数判断数字p是否是+Inf或-Inf。
+func isInf[P number](p P) bool {
+	return math.IsInf(float64(p), 0)
+}
@@ -0,0 +1,137 @@
+package ranges
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestNumberRangeNaNAndInf(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	for _, r := range []NumberRange[float64]{CreateNumberRange(nan, 1), CreateNumberRange(0, nan), CreateNumberRangeWithBounds(nan, nan, Closed, Closed)} {
+		if !r.IsEmpty() || r.IsIncludedPoint(0.5) || r.IsIncludedPoint(nan) || !r.Equal(NumberRange[float64]{}) {
+			t.Errorf("a range with a NaN endpoint should be empty, got %s", r.String())
+		}
+	}
+	if r := CreateNumberRangeWithBounds(nan, 1, Unbounded, Open); r.String() != "(-∞,1)" {
+		t.Errorf("an unbounded NaN endpoint should be ignored, got %s", r.String())
+	}
+	if r := CreateNumberRange(-inf, 5); !r.Equal(CreateNumberRangeTo(5.0, Open)) || !r.IsIncludedPoint(-math.MaxFloat64) || r.IsIncludedPoint(nan) {
+		t.Errorf("[-Inf,5) should equal (-∞,5), got %s", r.String())
+	}
+	if r := CreateNumberRangeWithBounds(-inf, inf, Closed, Closed); !r.Equal(CreateUnboundedNumberRange[float64]()) {
+		t.Errorf("[-Inf,+Inf] should be unbounded, got %s", r.String())
+	}
+	if r := CreateNumberRangeWithBounds(inf, inf, Open, Closed); !r.IsEmpty() {
+		t.Errorf("(+Inf,+Inf] should be empty, got %s", r.String())
+	}
+	if r := CreateNumberRange(inf, 0); r.String() != "[0,+∞)" {
+		t.Errorf("reversed infinite endpoint: got %s", r.String())
+	}
+	//+Inf的起点与-Inf的终点不包含任何数
+	for _, r := range []NumberRange[float64]{
+		CreateNumberRange(inf, inf), CreateNumberRange(-inf, -inf), CreateNumberRangeWithBounds(inf, inf, Closed, Closed),
+		CreateNumberRangeWithBounds(-inf, -inf, Closed, Closed), CreateNumberRangeFrom(inf, Closed), CreateNumberRangeTo(-inf, Closed),
+	} {
+		if !r.IsEmpty() || r.IsIncludedPoint(0) || r.IsIncludedPoint(-5) || r.IsIntersected(CreateNumberRange(-10.0, 0)) {
+			t.Errorf("a range from +Inf or to -Inf should be empty, got %s", r.String())
+		}
+		if ok, x := r.Intersect(CreateNumberRange(0.0, 1)); ok || !x.IsEmpty() {
+			t.Errorf("%s ∩ [0,1) = %s, %v", r.String(), x.String(), ok)
+		}
+	}
+	for _, text := range []string{"[NaN,5)", "(0, nan]", "[NaN,+∞)"} {
+		_, err := ParseNumberRange[float64](text)
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, ErrNaN) {
+			t.Errorf("ParseNumberRange(%q) should fail with ErrNaN, got %v", text, err)
+		}
+		var r NumberRange[float64]
+		if err := json.Unmarshal([]byte(strconv.Quote(text)), &r); !errors.As(err, &pe) || !errors.Is(err, ErrNaN) {
+			t.Errorf("UnmarshalJSON(%q) should fail with ErrNaN, got %s, %v", text, r.String(), err)
+		}
+	}
+	//无穷大的端点文本不能被当作数值，否则[+inf,5)会变成(5,+∞)
+	for _, text := range []string{"[+inf,5)", "[Infinity,5)", "(0,-inf]", "[1e400,2)"} {
+		var pe *ParseError
+		if r, err := ParseNumberRange[float64](text); !errors.As(err, &pe) {
+			t.Errorf("ParseNumberRange(%q) should fail, got %s", text, r.String())
+		}
+		var r NumberRange[float64]
+		if err := json.Unmarshal([]byte(strconv.Quote(text)), &r); !errors.As(err, &pe) {
+			t.Errorf("UnmarshalJSON(%q) should fail, got %s", text, r.String())
+		}
+		if err := r.Scan(text); !errors.As(err, &pe) {
+			t.Errorf("Scan(%q) should fail, got %s", text, r.String())
+		}
+	}
+	if _, err := ParseNumberRange[float64]("[+inf,5)"); !errors.Is(err, ErrInfinity) {
+		t.Errorf("ParseNumberRange([+inf,5)) should fail with ErrInfinity, got %v", err)
+	}
+	var f32 NumberRange[float32]
+	if r, err := ParseNumberRange[float32]("[1e39,2)"); err == nil {
+		t.Errorf("ParseNumberRange[float32]([1e39,2)) should fail, got %s", r.String())
+	}
+	if err := json.Unmarshal([]byte(`{"start":1e39,"end":2}`), &f32); err == nil {
+		t.Errorf("UnmarshalJSON of an overflowing float32 should fail, got %s", f32.String())
+	}
+	if r, err := ParseNumberRange[float32]("[1e38,+inf)"); err != nil || r.String() != "[1e+38,+∞)" {
+		t.Errorf("ParseNumberRange[float32]([1e38,+inf)) = %s, %v", r.String(), err)
+	}
+	if ok, x := CreateNumberRange(0.0, 1).Intersect(CreateNumberRange(2.0, 3)); ok || !x.IsEmpty() {
+		t.Errorf("Intersect of disjoint ranges should be empty, got %s", x.String())
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	tol := Tolerance[float64]{Epsilon: 1e-9}
+	x, y := 0.1, 0.2
+	a := CreateNumberRange(0, 0.3)
+	b := CreateNumberRange(x+y, 0.6)
+	if b.IsIntersected(a) || a.Equal(CreateNumberRange(0, x+y)) {
+		t.Fatalf("0.1+0.2 should differ from 0.3")
+	}
+	if !tol.Equal(a, CreateNumberRange(0, x+y)) || tol.Equal(a, CreateNumberRangeWithBounds(0, 0.3, Closed, Closed)) {
+		t.Errorf("Equal: wrong result")
+	}
+	if !tol.Equal(CreateNumberRangeTo(1.0, Open), CreateNumberRange(math.Inf(-1), 1+1e-12)) || tol.Equal(CreateNumberRangeTo(1.0, Open), CreateNumberRange(-1e300, 1)) {
+		t.Errorf("Equal with unbounded endpoints: wrong result")
+	}
+	if !tol.Equal(NumberRange[float64]{}, CreateNumberRange(2.0, 2)) || tol.Equal(NumberRange[float64]{}, a) {
+		t.Errorf("Equal with empty ranges: wrong result")
+	}
+	if !tol.IsConnected(a, b) || !tol.IsConnected(b, a) || tol.IsConnected(a, CreateNumberRange(0.31, 1)) {
+		t.Errorf("IsConnected: wrong result")
+	}
+	if ok, u := tol.Union(b, a); !ok || u.String() != "[0,0.6)" {
+		t.Errorf("Union: got %s, %v", u.String(), ok)
+	}
+	if ok, u := (Tolerance[float64]{}).Union(a, b); ok || u.String() != "[0,0.6)" {
+		t.Errorf("strict Union: got %s, %v", u.String(), ok)
+	}
+}
+
+func TestToleranceCoalesceCycles(t *testing.T) {
+	f := &NRCycleFunc[float64]{}
+	c := NumCycle[float64]{Count: 1, Unit: 0.1}
+	origin := CreateNumberRange(0, 0.1)
+	var rs []NumberRange[float64]
+	for n := 19; n >= 0; n-- {
+		rs = append(rs, f.OfCycles(origin, n, c))
+	}
+	if strict := Coalesce[float64, NumberRange[float64]](rs); len(strict) == 1 {
+		t.Fatalf("rounding should leave gaps between the cycles, got %v", strict)
+	}
+	got := Tolerance[float64]{Epsilon: 1e-9}.Coalesce(rs)
+	if len(got) != 1 || !(Tolerance[float64]{Epsilon: 1e-9}).Equal(got[0], CreateNumberRange(0.0, 2)) {
+		t.Errorf("Coalesce: got %v", got)
+	}
+	if got := (Tolerance[float64]{Epsilon: 1e-9}).Coalesce([]NumberRange[float64]{CreateNumberRange(2.0, 3), {}, CreateNumberRange(0.0, 1)}); len(got) != 2 || got[0].String() != "[0,1)" {
+		t.Errorf("Coalesce disjoint: got %v", got)
+	}
+	if rs[0].String() != f.OfCycles(origin, 19, c).String() {
+		t.Errorf("Coalesce should not modify its argument")
+	}
+}
@@ -0,0 +1,128 @@
+package ranges
+
+import (
+	"errors"
+	"time"
+)
+
+/**
+CreateNumberRange、CreateSeqRange等宽松的构造函数会悄悄地交换颠倒的起点与终点，
+对于来源不可靠的数据，这可能会掩盖数据本身的错误，比如，起止时间被写反了。
+本文件提供了严格的构造函数，比如NewNumberRange、NewTimeInterval，它们在端点不合法时返回*RangeError，
+可以使用errors.Is判断具体的原因：
+    if _, err := ranges.NewTimeInterval(start, end); errors.Is(err, ranges.ErrReversedEndpoints) {...}
+**/
+
+//严格的构造函数返回的错误原因，它们被包装在*RangeError中。
+var (
+	//ErrReversedEndpoints表示起点在终点之后。
+	ErrReversedEndpoints = errors.New("start is after end")
+	//ErrNaN表示端点是浮点数NaN。
+	ErrNaN = errors.New("endpoint is NaN")
+	//ErrInfinity表示端点是浮点数+Inf或-Inf，无穷大的端点应该使用Unbounded边界类型表示。
+	ErrInfinity = errors.New("endpoint is infinite")
+	//ErrZeroTime表示端点是time.Time的零值，这通常意味着时间没有被赋值。
+	ErrZeroTime = errors.New("endpoint is the zero time")
+	//ErrLocationMismatch表示起止时间使用了不同的时区。
+	ErrLocationMismatch = errors.New("start and end are in different locations")
+)
+
+//RangeError是严格的构造函数在端点不合法时返回的错误，记录了端点的字符串形式与错误原因。
+type RangeError struct {
+	Start string //起点的字符串形式
+	End   string //终点的字符串形式
+	Err   error  //错误原因，比如ErrReversedEndpoints
+}
+
+func (e *RangeError) Error() string {
+	return "ranges: invalid range from " + e.Start + " to " + e.End + ": " + e.Err.Error()
+}
+
+//Unwrap方法返回错误原因。
+func (e *RangeError) Unwrap() error {
+	return e.Err
+}
+
+//NewNumberRange函数用起点start与终点end创建一个左闭右开区间，与CreateNumberRange不同，
+//如果start大于end，或者端点是NaN、+Inf、-Inf，返回*RangeError。start等于end时，结果是空区间。
+//宽松的CreateNumberRange把-Inf的起点与+Inf的终点视为无界的端点，而严格的构造函数要求明确地使用Unbounded边界类型，
+//比如，NewNumberRangeWithBounds(0, 0, Unbounded, Open)。
+func NewNumberRange[P number](start, end P) (NumberRange[P], error) {
+	return NewNumberRangeWithBounds(start, end, Closed, Open)
+}
+
+//NewNumberRangeWithBounds函数用起点start、终点end及其边界类型创建一个区间，
+//有界的端点需要满足与NewNumberRange相同的要求，无界端点的值被忽略。
+func NewNumberRangeWithBounds[P number](start, end P, left, right BoundType) (NumberRange[P], error) {
+	var reason error
+	switch {
+	case left != Unbounded && isNaN(start), right != Unbounded && isNaN(end):
+		reason = ErrNaN
+	case left != Unbounded && isInf(start), right != Unbounded && isInf(end):
+		reason = ErrInfinity
+	case left != Unbounded && right != Unbounded && start > end:
+		reason = ErrReversedEndpoints
+	}
+	if reason != nil {
+		return NumberRange[P]{}, &RangeError{Start: v2s(start), End: v2s(end), Err: reason}
+	}
+	return CreateNumberRangeWithBounds(start, end, left, right), nil
+}
+
+//NewSeqRange函数用起点start与终点end创建一个左闭右开区间，与CreateSeqRange不同，
+//如果start在end之后，返回*RangeError。
+func NewSeqRange[P Sequencable[T], T any](start, end P) (SeqRange[P, T], error) {
+	return NewSeqRangeWithBounds[P, T](start, end, Closed, Open)
+}
+
+//NewSeqRangeWithBounds函数用起点start、终点end及其边界类型创建一个区间，
+//如果两个端点都有界并且start在end之后，返回*RangeError。
+func NewSeqRangeWithBounds[P Sequencable[T], T any](start, end P, left, right BoundType) (SeqRange[P, T], error) {
+	if left != Unbounded && right != Unbounded && start.After(typeTo[P, T](end)) {
+		return SeqRange[P, T]{}, &RangeError{Start: v2s(start), End: v2s(end), Err: ErrReversedEndpoints}
+	}
+	return CreateSeqRangeWithBounds[P, T](start, end, left, right), nil
+}
+
+//NewTimeInterval函数用开始时间start与结束时间end创建一个左闭右开的时间段，与CreateTimeInterval不同，
+//如果start在end之后，或者有时间是零值，或者二者的时区不同，返回*RangeError。
+func NewTimeInterval(start, end time.Time) (TimeInterval, error) {
+	return NewTimeIntervalWithBounds(start, end, Closed, Open)
+}
+
+//NewTimeIntervalWithBounds函数用开始时间start、结束时间end及其边界类型创建一个时间段，
+//有界的端点需要满足与NewTimeInterval相同的要求，无界端点的值被忽略。
+func NewTimeIntervalWithBounds(start, end time.Time, left, right BoundType) (TimeInterval, error) {
+	var reason error
+	bounded := left != Unbounded && right != Unbounded
+	switch {
+	case left != Unbounded && start.IsZero(), right != Unbounded && end.IsZero():
+		reason = ErrZeroTime
+	case bounded && !isSameLocation(start, end):
+		reason = ErrLocationMismatch
+	case bounded && start.After(end):
+		reason = ErrReversedEndpoints
+	}
+	if reason != nil {
+		return TimeInterval{}, &RangeError{Start: start.Format(time.RFC3339Nano), End: end.Format(time.RFC3339Nano), Err: reason}
+	}
+	return CreateTimeIntervalWithBounds(start, end, left, right), nil
+}
+
+//isSameLocation函数判断两个时间点的时区是否相同。名称相同的时区被视为相同，
+//没有名称的固定时区（比如解析“+08:00”得到的时区）则比较其偏移量。
+func isSameLocation(a, b time.Time) bool {
+	la, lb := a.Location(), b.Location()
+	if la == lb {
+		return true
+	}
+	if la.String() != lb.String() {
+		return false
+	}
+	if la.String() != "" {
+		return true
+	}
+	_, aOffset := a.Zone()
+	_, bOffset := b.Zone()
+	return aOffset == bOffset
+}
@@ -0,0 +1,75 @@
+package ranges
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewNumberRange(t *testing.T) {
+	if r, err := NewNumberRange(1, 5); err != nil || r.String() != "[1,5)" {
+		t.Errorf("NewNumberRange(1,5) = %s, %v", r.String(), err)
+	}
+	if r, err := NewNumberRange(3, 3); err != nil || !r.IsEmpty() {
+		t.Errorf("NewNumberRange(3,3) = %s, %v", r.String(), err)
+	}
+	_, err := NewNumberRange(5, 1)
+	var re *RangeError
+	if !errors.Is(err, ErrReversedEndpoints) || !errors.As(err, &re) || re.Start != "5" || re.End != "1" {
+		t.Errorf("NewNumberRange(5,1): got %v", err)
+	}
+	if _, err := NewNumberRange(math.NaN(), 1); !errors.Is(err, ErrNaN) {
+		t.Errorf("NaN start: got %v", err)
+	}
+	if _, err := NewNumberRange(0, math.Inf(1)); !errors.Is(err, ErrInfinity) {
+		t.Errorf("infinite end: got %v", err)
+	}
+	for _, r := range [][2]float64{{math.Inf(-1), math.Inf(1)}, {math.Inf(1), 0}, {math.Inf(1), math.Inf(1)}, {math.Inf(-1), math.Inf(-1)}} {
+		if _, err := NewNumberRangeWithBounds(r[0], r[1], Closed, Closed); !errors.Is(err, ErrInfinity) {
+			t.Errorf("NewNumberRangeWithBounds(%v, %v): got %v", r[0], r[1], err)
+		}
+	}
+	if r, err := NewNumberRangeWithBounds(math.Inf(-1), 0, Unbounded, Open); err != nil || r.String() != "(-∞,0)" {
+		t.Errorf("an unbounded infinite endpoint should be ignored, got %s, %v", r.String(), err)
+	}
+	if r, err := NewNumberRangeWithBounds(5, 0, Closed, Unbounded); err != nil || r.String() != "[5,+∞)" {
+		t.Errorf("NewNumberRangeWithBounds = %s, %v", r.String(), err)
+	}
+	if _, err := NewNumberRangeWithBounds(5.0, math.NaN(), Closed, Closed); !errors.Is(err, ErrNaN) {
+		t.Errorf("NaN end: got %v", err)
+	}
+}
+
+func TestNewTimeInterval(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	if ti, err := NewTimeInterval(start, end); err != nil || !ti.Equal(CreateTimeInterval(start, end)) {
+		t.Errorf("NewTimeInterval = %s, %v", Tintvl2Str(ti), err)
+	}
+	if _, err := NewTimeInterval(end, start); !errors.Is(err, ErrReversedEndpoints) {
+		t.Errorf("reversed: got %v", err)
+	}
+	if _, err := NewSeqRange[time.Time, time.Time](end, start); !errors.Is(err, ErrReversedEndpoints) {
+		t.Errorf("NewSeqRange reversed: got %v", err)
+	}
+	if _, err := NewTimeInterval(time.Time{}, end); !errors.Is(err, ErrZeroTime) {
+		t.Errorf("zero start: got %v", err)
+	}
+	if ti, err := NewTimeIntervalWithBounds(start, time.Time{}, Closed, Unbounded); err != nil || !ti.IsIncludedPoint(end) {
+		t.Errorf("unbounded end: got %s, %v", Tintvl2Str(ti), err)
+	}
+	shanghai := time.FixedZone("CST", 8*3600)
+	if _, err := NewTimeInterval(start, end.In(shanghai)); !errors.Is(err, ErrLocationMismatch) {
+		t.Errorf("different locations: got %v", err)
+	}
+	p1, _ := time.Parse(time.RFC3339, "2022-01-01T08:00:00+08:00")
+	p2, _ := time.Parse(time.RFC3339, "2022-01-01T09:00:00+08:00")
+	p3, _ := time.Parse(time.RFC3339, "2022-01-01T09:00:00+09:00")
+	if _, err := NewTimeInterval(p1, p2); err != nil {
+		t.Errorf("same fixed offsets: got %v", err)
+	}
+	if _, err := NewTimeInterval(p1, p3); !errors.Is(err, ErrLocationMismatch) {
+		t.Errorf("different fixed offsets: got %v", err)
+	}
+}
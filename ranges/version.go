@@ -0,0 +1,240 @@
+package ranges
+
+import (
+	"strconv"
+	"strings"
+)
+
+//Version定义了语义化版本（SemVer 2.0），比如，1.2.3、1.0.0-alpha.1、1.0.0+20220101。
+//版本的先后顺序（precedence）依次比较主版本号、次版本号与修订号，预发布版本在相应的正式版本之前，
+//预发布标识符逐个比较：数字标识符按数值比较，并且在字母数字标识符之前，字母数字标识符按ASCII顺序比较，
+//标识符较少的版本在前，比如：
+//    1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-alpha.beta < 1.0.0-beta < 1.0.0-beta.2 < 1.0.0-beta.11 < 1.0.0-rc.1 < 1.0.0
+//构建元数据（Build）不参与比较，所以1.0.0+a与1.0.0+b是相同的版本（Equal），但不是==的。
+//Version满足Sequencable[Version]约束，可以作为SeqRange的端点，参见VersionRange。
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string //点分隔的预发布标识符，比如，alpha.1，空字符串表示正式版本
+	Build      string //点分隔的构建元数据，比如，20220101.sha.5114f85
+}
+
+//ParseVersion函数解析语义化版本的文本，比如，1.2.3-beta.1+exp.sha.5114f85，文本之前可以有一个“v”，比如，v1.2.3。
+func ParseVersion(s string) (Version, error) {
+	v, n, err := parsePartialVersion(s, false)
+	if err != nil {
+		return Version{}, err
+	}
+	if n < 3 {
+		return Version{}, &ParseError{Text: s, Msg: "version must have major, minor and patch numbers"}
+	}
+	return v, nil
+}
+
+//Compare方法按照语义化版本的先后顺序比较两个版本，v在other之前返回-1，v在other之后返回1，二者相同返回0。
+func (v Version) Compare(other Version) int {
+	switch {
+	case v.Major != other.Major:
+		return compareUint(v.Major, other.Major)
+	case v.Minor != other.Minor:
+		return compareUint(v.Minor, other.Minor)
+	case v.Patch != other.Patch:
+		return compareUint(v.Patch, other.Patch)
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+//Equal方法判断两个版本的先后顺序是否相同，构建元数据被忽略。
+func (v Version) Equal(other Version) bool {
+	return v.Compare(other) == 0
+}
+
+//Before方法判断版本v是否在other之前。
+func (v Version) Before(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+//After方法判断版本v是否在other之后。
+func (v Version) After(other Version) bool {
+	return v.Compare(other) > 0
+}
+
+//IsPrerelease方法判断版本是否是预发布版本。
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+//String方法输出版本的文本形式，比如，1.2.3-beta.1+exp.sha.5114f85。
+func (v Version) String() string {
+	s := strconv.FormatUint(v.Major, 10) + "." + strconv.FormatUint(v.Minor, 10) + "." + strconv.FormatUint(v.Patch, 10)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+//MarshalText方法将版本编码为文本，因而，版本编码为JSON字符串，比如，"1.2.3"。
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+//UnmarshalText方法解析版本的文本。
+func (v *Version) UnmarshalText(text []byte) error {
+	result, err := ParseVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = result
+	return nil
+}
+
+//successor方法返回紧接在版本v之后的版本，也就是在v之后的所有版本中最早的一个，因而，“>v”等价于“>=v.successor()”。
+//正式版本之后是下一个修订号的最早的预发布版本，比如，1.2.3之后是1.2.4-0；
+//预发布版本之后是在其标识符后面加上“.0”的版本，比如，1.2.3-alpha之后是1.2.3-alpha.0。
+func (v Version) successor() Version {
+	if v.Prerelease == "" {
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1, Prerelease: "0"}
+	}
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: v.Prerelease + ".0"}
+}
+
+//VersionRange是由语义化版本构成的区间，比如，[1.2.3,2.0.0-0)。
+type VersionRange = SeqRange[Version, Version]
+
+//CreateVersionRange函数用给定的两个版本创建一个左闭右开的版本区间。
+func CreateVersionRange(v1, v2 Version) VersionRange {
+	return CreateSeqRange[Version, Version](v1, v2)
+}
+
+//parsePartialVersion函数解析可能不完整的版本，返回版本以及给出的版本号的个数，比如，“1.2”给出了2个版本号。
+//wildcard为true时，版本号可以是通配符“x”、“X”或者“*”，通配符及其之后的部分被视为没有给出，比如，“1.x”只给出了1个版本号。
+//只有给出全部3个版本号时，才可以有预发布标识符与构建元数据。
+func parsePartialVersion(s string, wildcard bool) (v Version, n int, err error) {
+	text := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(text, '+'); i >= 0 {
+		text, v.Build = text[:i], text[i+1:]
+		if err = checkIdentifiers(v.Build, false); err != nil {
+			return Version{}, 0, &ParseError{Text: s, Msg: "invalid build metadata", Err: err}
+		}
+	}
+	if i := strings.IndexByte(text, '-'); i >= 0 {
+		text, v.Prerelease = text[:i], text[i+1:]
+		if err = checkIdentifiers(v.Prerelease, true); err != nil {
+			return Version{}, 0, &ParseError{Text: s, Msg: "invalid pre-release", Err: err}
+		}
+	}
+	fields := strings.Split(text, ".")
+	if len(fields) > 3 {
+		return Version{}, 0, &ParseError{Text: s, Msg: "too many version numbers"}
+	}
+	numbers := [3]*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, f := range fields {
+		if wildcard && (f == "x" || f == "X" || f == "*") {
+			for _, rest := range fields[i+1:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return Version{}, 0, &ParseError{Text: s, Msg: "version number after a wildcard"}
+				}
+			}
+			break
+		}
+		if *numbers[i], err = parseVersionNumber(f); err != nil {
+			return Version{}, 0, &ParseError{Text: s, Msg: "invalid version number", Err: err}
+		}
+		n++
+	}
+	if n < 3 && (v.Prerelease != "" || v.Build != "") {
+		return Version{}, 0, &ParseError{Text: s, Msg: "pre-release or build metadata on a partial version"}
+	}
+	return v, n, nil
+}
+
+//parseVersionNumber函数解析版本号，版本号是没有前导0的非负整数。
+func parseVersionNumber(s string) (uint64, error) {
+	if len(s) > 1 && s[0] == '0' {
+		return 0, strconv.ErrSyntax
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, strconv.ErrSyntax
+		}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+//checkIdentifiers函数检查点分隔的标识符，标识符由ASCII字母、数字与“-”构成，不能为空，
+//numeric为true时，数字标识符不能有前导0（预发布标识符的要求）。
+func checkIdentifiers(s string, numeric bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return strconv.ErrSyntax
+		}
+		for _, c := range id {
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return strconv.ErrSyntax
+			}
+		}
+		if numeric && isNumericIdentifier(id) && len(id) > 1 && id[0] == '0' {
+			return strconv.ErrSyntax
+		}
+	}
+	return nil
+}
+
+//comparePrerelease函数比较两个版本的预发布标识符，没有预发布标识符的正式版本在所有预发布版本之后。
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(as)), uint64(len(bs)))
+}
+
+//compareIdentifier函数比较两个预发布标识符，数字标识符按数值比较，并且在字母数字标识符之前。
+func compareIdentifier(a, b string) int {
+	aNum, bNum := isNumericIdentifier(a), isNumericIdentifier(b)
+	switch {
+	case aNum && bNum:
+		//没有前导0的数字，较长的数值较大
+		if len(a) != len(b) {
+			return compareUint(uint64(len(a)), uint64(len(b)))
+		}
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumericIdentifier(id string) bool {
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return id != ""
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+package ranges
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVersionPrecedence(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1-0", "1.0.1", "1.2.0", "1.10.0", "2.0.0",
+	}
+	versions := make([]Version, len(ordered))
+	for i, s := range ordered {
+		v, err := ParseVersion(s)
+		if err != nil || v.String() != s {
+			t.Fatalf("ParseVersion(%q) = %s, %v", s, v, err)
+		}
+		versions[i] = v
+	}
+	for i := range versions {
+		for j := range versions {
+			want := compareUint(uint64(i), uint64(j))
+			if got := versions[i].Compare(versions[j]); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", versions[i], versions[j], got, want)
+			}
+		}
+	}
+	a, _ := ParseVersion("v1.0.0+build.1")
+	b, _ := ParseVersion("1.0.0+build.2")
+	if !a.Equal(b) || a == b || a.String() != "1.0.0+build.1" {
+		t.Errorf("build metadata should be ignored in precedence")
+	}
+	for _, s := range []string{"1.2", "1.2.3.4", "01.2.3", "1.2.3-01", "1.2.3-", "1.2.3-a..b", "1.2.3+", "1.2.x", "1.2.3-a_b", ""} {
+		if _, err := ParseVersion(s); err == nil {
+			t.Errorf("ParseVersion(%q) should fail", s)
+		}
+	}
+	var r VersionRange
+	if err := json.Unmarshal([]byte(`{"start":"1.2.3","end":"2.0.0-0"}`), &r); err != nil || r.String() != "[1.2.3,2.0.0-0)" {
+		t.Errorf("UnmarshalJSON: got %s, %v", r.String(), err)
+	}
+}
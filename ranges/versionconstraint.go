@@ -0,0 +1,134 @@
+package ranges
+
+import "strings"
+
+//ParseVersionConstraint函数解析npm/Cargo风格的版本约束表达式，返回满足约束的所有版本构成的区间集合，
+//集合中的区间都是左闭右开的（或者无界的），可以使用RangeSet的方法判断版本是否满足约束，以及对约束求交集、并集。
+//约束表达式由“||”分隔的若干备选项构成，满足任何一个备选项即满足约束；每个备选项由空白或者逗号分隔的若干比较式构成，
+//需要同时满足所有的比较式。比较式有如下几种形式：
+//    >=1.2.3  >1.2.3  <=1.2.3  <1.2.3     比较，>1.2.3即>=1.2.4-0，<=1.2.3即<1.2.4-0
+//    =1.2.3  1.2.3                       精确匹配，即[1.2.3,1.2.4-0)
+//    ^1.2.3                              与1.2.3兼容，不改变最左边的非0版本号，即[1.2.3,2.0.0-0)，^0.2.3即[0.2.3,0.3.0-0)
+//    ~1.2.3                              只改变修订号，即[1.2.3,1.3.0-0)
+//    1.2.x  1.2  1.x  *                  通配符，省略的版本号与通配符相同，即[1.2.0,1.3.0-0)、[1.0.0,2.0.0-0)以及全部版本
+//比较符与版本之间可以有空白，比较式中的版本也可以是不完整的，比如，^1.2即[1.2.0,2.0.0-0)，>1.2即>=1.3.0-0，<1.2即<1.2.0-0。
+//与npm不同，区间只按照版本的先后顺序计算，不会特别排除预发布版本，比如，>=1.0.0 <2.0.0包含1.5.0-beta。
+//上界使用“-0”结尾的版本，比如，2.0.0-0，它是2.0.0的最早的预发布版本，因而，^1.2.3不包含2.0.0-alpha，
+//^1.2 || ^2也不会被合并为一个区间，二者之间是2.0.0的预发布版本。
+func ParseVersionConstraint(s string) (RangeSet[Version, VersionRange], error) {
+	var alternatives []VersionRange
+	for _, alt := range strings.Split(s, "||") {
+		r, err := parseVersionComparators(s, alt)
+		if err != nil {
+			return RangeSet[Version, VersionRange]{}, err
+		}
+		alternatives = append(alternatives, r)
+	}
+	return NewRangeSet[Version, VersionRange](alternatives...), nil
+}
+
+//parseVersionComparators函数解析约束表达式s中的一个备选项alt，返回同时满足其中所有比较式的版本区间。
+func parseVersionComparators(s, alt string) (VersionRange, error) {
+	tokens := strings.FieldsFunc(alt, func(c rune) bool {
+		return c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r'
+	})
+	if len(tokens) == 0 {
+		return VersionRange{}, &ParseError{Text: s, Msg: "empty version constraint"}
+	}
+	result := CreateSeqRangeWithBounds[Version, Version](Version{}, Version{}, Unbounded, Unbounded)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		//比较符与版本之间有空白，比如，“>= 1.2.3”
+		if strings.Trim(token, "<>=^~") == "" && i+1 < len(tokens) {
+			i++
+			token += tokens[i]
+		}
+		r, err := parseVersionComparator(s, token)
+		if err != nil {
+			return VersionRange{}, err
+		}
+		_, result = result.Intersect(r)
+	}
+	return result, nil
+}
+
+//parseVersionComparator函数将一个比较式转换为版本区间。
+func parseVersionComparator(s, token string) (VersionRange, error) {
+	op := token[:len(token)-len(strings.TrimLeft(token, "<>=^~"))]
+	v, n, err := parsePartialVersion(token[len(op):], true)
+	if err != nil {
+		return VersionRange{}, &ParseError{Text: s, Msg: "invalid version in " + token, Err: err}
+	}
+	//next是与v给出的版本号不同的最早的版本，比如，1.2之后是1.3.0-0，1.2.3之后是1.2.4-0
+	next := v.successor()
+	switch n {
+	case 0:
+		next = Version{}
+	case 1:
+		next = Version{Major: v.Major + 1, Prerelease: "0"}
+	case 2:
+		next = Version{Major: v.Major, Minor: v.Minor + 1, Prerelease: "0"}
+	}
+	from := func(lower Version) VersionRange {
+		return CreateSeqRangeFrom[Version, Version](lower, Closed)
+	}
+	to := func(upper Version) VersionRange {
+		return CreateSeqRangeTo[Version, Version](upper, Open)
+	}
+	between := func(lower, upper Version) VersionRange {
+		return CreateVersionRange(lower, upper)
+	}
+	all := CreateSeqRangeWithBounds[Version, Version](Version{}, Version{}, Unbounded, Unbounded)
+	v.Build = ""
+	switch op {
+	case "", "=":
+		if n == 0 {
+			return all, nil
+		}
+		return between(v, next), nil
+	case ">=":
+		if n == 0 {
+			return all, nil
+		}
+		return from(v), nil
+	case ">":
+		if n == 0 {
+			return VersionRange{}, nil
+		}
+		return from(next), nil
+	case "<=":
+		if n == 0 {
+			return all, nil
+		}
+		return to(next), nil
+	case "<":
+		if n == 0 {
+			return VersionRange{}, nil
+		}
+		if n < 3 {
+			v.Prerelease = "0"
+		}
+		return to(v), nil
+	case "~":
+		if n == 0 {
+			return all, nil
+		}
+		if n == 3 {
+			next = Version{Major: v.Major, Minor: v.Minor + 1, Prerelease: "0"}
+		}
+		return between(v, next), nil
+	case "^":
+		switch {
+		case n == 0:
+			return all, nil
+		case v.Major != 0 || n == 1:
+			next = Version{Major: v.Major + 1, Prerelease: "0"}
+		case v.Minor != 0 || n == 2:
+			next = Version{Minor: v.Minor + 1, Prerelease: "0"}
+		default:
+			next = Version{Patch: v.Patch + 1, Prerelease: "0"}
+		}
+		return between(v, next), nil
+	}
+	return VersionRange{}, &ParseError{Text: s, Msg: "invalid operator " + op}
+}
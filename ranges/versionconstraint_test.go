@@ -0,0 +1,68 @@
+package ranges
+
+import "testing"
+
+func TestParseVersionConstraint(t *testing.T) {
+	cases := map[string]string{
+		"^1.2.3":                 "{[1.2.3,2.0.0-0)}",
+		"^1.2":                   "{[1.2.0,2.0.0-0)}",
+		"^0.2.3":                 "{[0.2.3,0.3.0-0)}",
+		"^0.0.3":                 "{[0.0.3,0.0.4-0)}",
+		"^0.0":                   "{[0.0.0,0.1.0-0)}",
+		"^0":                     "{[0.0.0,1.0.0-0)}",
+		"^1.2.3-beta.2":          "{[1.2.3-beta.2,2.0.0-0)}",
+		"~1.4.0":                 "{[1.4.0,1.5.0-0)}",
+		"~1.4":                   "{[1.4.0,1.5.0-0)}",
+		"~1":                     "{[1.0.0,2.0.0-0)}",
+		">=1.0 <2.0":             "{[1.0.0,2.0.0-0)}",
+		">= 1.0, < 2.0":          "{[1.0.0,2.0.0-0)}",
+		">=1.0 <2.0 || 3.x":      "{[1.0.0,2.0.0-0),[3.0.0,4.0.0-0)}",
+		">1.2.3":                 "{[1.2.4-0,+∞)}",
+		">1.2":                   "{[1.3.0-0,+∞)}",
+		"<=1.2.3":                "{(-∞,1.2.4-0)}",
+		"<1.2":                   "{(-∞,1.2.0-0)}",
+		"=1.2.3":                 "{[1.2.3,1.2.4-0)}",
+		"1.2.x":                  "{[1.2.0,1.3.0-0)}",
+		"1.*.*":                  "{[1.0.0,2.0.0-0)}",
+		"*":                      "{(-∞,+∞)}",
+		"^1.2 || ^1.5 || ^2":     "{[1.2.0,2.0.0-0),[2.0.0,3.0.0-0)}",
+		">=2.0 <1.0":             "{}",
+		"<1.0 || >=1.0":          "{(-∞,1.0.0-0),[1.0.0,+∞)}",
+		"<=1.0 || >1.0":          "{(-∞,+∞)}",
+		"1.2.3-alpha":            "{[1.2.3-alpha,1.2.3-alpha.0)}",
+		">1.2.3-alpha <1.2.3":    "{[1.2.3-alpha.0,1.2.3)}",
+		"~1.2.3 || >=1.2.5 <1.3": "{[1.2.3,1.3.0-0)}",
+	}
+	for text, want := range cases {
+		set, err := ParseVersionConstraint(text)
+		if err != nil || set.String() != want {
+			t.Errorf("ParseVersionConstraint(%q) = %s, %v, want %s", text, set.String(), err, want)
+		}
+	}
+	for _, text := range []string{"", "^1.2 ||", "=>1.0", "^1.x.3", "1.2.3.4", ">=", "~1.2-beta"} {
+		if _, err := ParseVersionConstraint(text); err == nil {
+			t.Errorf("ParseVersionConstraint(%q) should fail", text)
+		}
+	}
+}
+
+func TestVersionConstraintMatching(t *testing.T) {
+	caret, _ := ParseVersionConstraint("^1.2.3")
+	for s, want := range map[string]bool{
+		"1.2.3": true, "1.9.9": true, "1.5.0-beta": true, "1.2.3-beta": false,
+		"2.0.0": false, "2.0.0-alpha": false, "1.2.2": false,
+	} {
+		v, _ := ParseVersion(s)
+		if got := caret.Contains(v); got != want {
+			t.Errorf("^1.2.3 Contains(%s) = %v", s, got)
+		}
+	}
+	plugin, _ := ParseVersionConstraint(">=1.0 <2.0 || 3.x")
+	host, _ := ParseVersionConstraint("~1.4.0 || ^3.1")
+	if got := plugin.Intersect(host).String(); got != "{[1.4.0,1.5.0-0),[3.1.0,4.0.0-0)}" {
+		t.Errorf("Intersect: got %s", got)
+	}
+	if got := caret.Union(host).String(); got != "{[1.2.3,2.0.0-0),[3.1.0,4.0.0-0)}" {
+		t.Errorf("Union: got %s", got)
+	}
+}